@@ -0,0 +1,66 @@
+package buuid
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// FixedWeightBytes generates ceil(totalBits/8) bytes with exactly setBits of the first totalBits bits set,
+// chosen uniformly among all such patterns, bit i is stored in byte i/8 at position i%8 (least significant first).
+// example: FixedWeightBytes(64, 5)
+func FixedWeightBytes(totalBits, setBits int) ([]byte, error) {
+	if totalBits < 0 || setBits < 0 {
+		return nil, errors.New("buuid: totalBits and setBits must be non-negative")
+	}
+	if setBits > totalBits {
+		return nil, errors.New("buuid: setBits must not exceed totalBits")
+	}
+
+	// Partial Fisher-Yates shuffle, the first setBits positions are a uniform sample without replacement.
+	positions := make([]int, totalBits)
+	for i := range positions {
+		positions[i] = i
+	}
+	for i := 0; i < setBits; i++ {
+		j := i + randIntn(totalBits-i)
+		positions[i], positions[j] = positions[j], positions[i]
+	}
+
+	result := make([]byte, (totalBits+7)/8)
+	for _, p := range positions[:setBits] {
+		result[p/8] |= 1 << (p % 8)
+	}
+
+	return result, nil
+}
+
+// RandomBitset generates a packed bitset of bits bits, each set independently with probability 1/2,
+// bit i is stored in word i/64 at position i%64. Bits past the length in the last word are always zero,
+// an empty bitset is returned if bits <= 0.
+func RandomBitset(bits int) []uint64 {
+	if bits <= 0 {
+		return []uint64{}
+	}
+
+	words := (bits + 63) / 64
+	buf := make([]byte, 8*words)
+	readRandom(buf)
+
+	bs := make([]uint64, words)
+	for i := range bs {
+		bs[i] = binary.LittleEndian.Uint64(buf[8*i:])
+	}
+	if tail := bits % 64; tail != 0 {
+		bs[words-1] &= 1<<tail - 1
+	}
+
+	return bs
+}
+
+// TestBit reports whether bit i of the bitset bs is set, false if i is out of range.
+func TestBit(bs []uint64, i int) bool {
+	if i < 0 || i/64 >= len(bs) {
+		return false
+	}
+	return bs[i/64]&(1<<(i%64)) != 0
+}
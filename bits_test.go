@@ -0,0 +1,75 @@
+package buuid
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixedWeightBytes(t *testing.T) {
+	for _, c := range [][2]int{{0, 0}, {1, 1}, {13, 0}, {13, 13}, {64, 5}, {100, 37}} {
+		for i := 0; i < 100; i++ {
+			b, err := FixedWeightBytes(c[0], c[1])
+			assert.NoError(t, err)
+			assert.Equal(t, (c[0]+7)/8, len(b))
+
+			count := 0
+			for _, v := range b {
+				count += bits.OnesCount8(v)
+			}
+			assert.Equal(t, c[1], count)
+
+			// bits past totalBits are never set
+			if c[0]%8 != 0 {
+				assert.Equal(t, byte(0), b[len(b)-1]>>(c[0]%8))
+			}
+		}
+	}
+
+	_, err := FixedWeightBytes(8, 9)
+	assert.Error(t, err)
+	_, err = FixedWeightBytes(-1, 0)
+	assert.Error(t, err)
+}
+
+func TestRandomBitset(t *testing.T) {
+	assert.Equal(t, 0, len(RandomBitset(0)))
+
+	ones, total := 0, 0
+	for _, n := range []int{1, 63, 64, 65, 100, 1000} {
+		for i := 0; i < 100; i++ {
+			bs := RandomBitset(n)
+			assert.Equal(t, (n+63)/64, len(bs))
+
+			// tail bits are always zero
+			for j := n; j < len(bs)*64; j++ {
+				assert.False(t, TestBit(bs, j))
+			}
+			for _, w := range bs {
+				ones += bits.OnesCount64(w)
+			}
+			total += n
+		}
+	}
+	assert.InDelta(t, 0.5, float64(ones)/float64(total), 0.02)
+
+	bs := []uint64{1 << 3, 1}
+	assert.True(t, TestBit(bs, 3))
+	assert.True(t, TestBit(bs, 64))
+	assert.False(t, TestBit(bs, 4))
+	assert.False(t, TestBit(bs, -1))
+	assert.False(t, TestBit(bs, 128))
+}
+
+func BenchmarkFixedWeightBytes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = FixedWeightBytes(256, 32)
+	}
+}
+
+func BenchmarkRandomBitset_4096(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		RandomBitset(4096)
+	}
+}
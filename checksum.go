@@ -0,0 +1,183 @@
+package buuid
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// ean13CheckDigit returns the EAN-13 check digit of the first 12 digits of s,
+// digits are weighted 1 and 3 alternately and the check digit brings the sum to a multiple of 10.
+func ean13CheckDigit(s string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := int(s[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// isDigits reports whether s consists only of ASCII digits.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ISBN13 generates a random valid ISBN-13 of 13 digits without hyphens, prefixed with 978 or 979.
+// example: 9781234567897
+func ISBN13() string {
+	prefix := "978"
+	if randIntn(2) == 1 {
+		prefix = "979"
+	}
+	s := prefix + String(R_NUM, 9)
+	return s + string(ean13CheckDigit(s))
+}
+
+// ValidISBN13 reports whether s is 13 digits with a 978 or 979 prefix and a correct check digit.
+func ValidISBN13(s string) bool {
+	if len(s) != 13 || !isDigits(s) || (s[:3] != "978" && s[:3] != "979") {
+		return false
+	}
+	return s[12] == ean13CheckDigit(s)
+}
+
+// EAN13 generates a random valid EAN-13 barcode number of 13 digits, 12 random digits followed by
+// the mod-10 check digit. The first digit is never 9 and the prefix is never 2, which are reserved
+// for ISBN, ISSN, coupons and in-store numbers, so the number looks like a regular product code.
+// example: 4006381333931
+func EAN13() string {
+	const first = "01345678"
+	s := string(first[randIntn(len(first))]) + String(R_NUM, 11)
+	return s + string(ean13CheckDigit(s))
+}
+
+// ValidEAN13 reports whether s is 13 digits with a correct EAN-13 check digit.
+func ValidEAN13(s string) bool {
+	return len(s) == 13 && isDigits(s) && s[12] == ean13CheckDigit(s)
+}
+
+// ibanFormats holds the BBAN structure of each supported IBAN country in SWIFT notation:
+// a run of n digits is "Nn", of uppercase letters "Na" and of uppercase alphanumerics "Nc".
+// The IBAN length is 4, for the country code and check digits, plus the BBAN length.
+var ibanFormats = map[string]string{
+	"AT": "16n",
+	"BE": "12n",
+	"CH": "5n12c",
+	"DE": "18n",
+	"DK": "14n",
+	"ES": "20n",
+	"FI": "14n",
+	"FR": "10n11c2n",
+	"GB": "4a14n",
+	"IE": "4a14n",
+	"IT": "1a10n12c",
+	"LU": "3n13c",
+	"NL": "4a10n",
+	"NO": "11n",
+	"PL": "24n",
+	"PT": "21n",
+	"SE": "20n",
+}
+
+// ibanSegments calls fn for each run of the BBAN format f with the run length and its character class.
+func ibanSegments(f string, fn func(n int, class byte)) {
+	n := 0
+	for i := 0; i < len(f); i++ {
+		if c := f[i]; c >= '0' && c <= '9' {
+			n = n*10 + int(c-'0')
+			continue
+		}
+		fn(n, f[i])
+		n = 0
+	}
+}
+
+// ibanClassChars returns the characters of a BBAN format class.
+func ibanClassChars(class byte) []byte {
+	switch class {
+	case 'a':
+		return upperChars
+	case 'c':
+		return kindChars(R_NUM | R_UPPER)
+	default:
+		return numChars
+	}
+}
+
+// ibanMod97 returns the ISO 7064 mod 97-10 remainder of s, letters count as 10 to 35.
+func ibanMod97(s string) int {
+	rem := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'A' && c <= 'Z' {
+			rem = (rem*100 + int(c-'A') + 10) % 97
+		} else {
+			rem = (rem*10 + int(c-'0')) % 97
+		}
+	}
+	return rem
+}
+
+// IBAN generates a random structurally valid IBAN for the ISO 3166 country code, without spaces,
+// with the country's BBAN length and character classes and correct mod-97 check digits.
+// The bank and account parts are random, so the bank does not exist.
+// Supported countries are AT, BE, CH, DE, DK, ES, FI, FR, GB, IE, IT, LU, NL, NO, PL, PT and SE.
+// example: IBAN("DE") returns DE89370400440532013000
+func IBAN(country string) (string, error) {
+	country = strings.ToUpper(country)
+	f, ok := ibanFormats[country]
+	if !ok {
+		return "", fmt.Errorf("buuid: unsupported IBAN country %q", country)
+	}
+
+	var bban []byte
+	ibanSegments(f, func(n int, class byte) {
+		chars := ibanClassChars(class)
+		for i := 0; i < n; i++ {
+			bban = append(bban, chars[randIntn(len(chars))])
+		}
+	})
+
+	check := 98 - ibanMod97(string(bban)+country+"00")
+	return fmt.Sprintf("%s%02d%s", country, check, bban), nil
+}
+
+// ValidIBAN reports whether s is a valid IBAN of a country supported by IBAN: the length and
+// character classes match the country's BBAN structure and the mod-97 check passes.
+// Spaces, as in the grouped print form, are ignored, letters must be uppercase.
+func ValidIBAN(s string) bool {
+	s = strings.ReplaceAll(s, " ", "")
+	if len(s) < 4 || !isDigits(s[2:4]) {
+		return false
+	}
+	f, ok := ibanFormats[s[:2]]
+	if !ok {
+		return false
+	}
+
+	bban, valid := s[4:], true
+	pos := 0
+	ibanSegments(f, func(n int, class byte) {
+		chars := ibanClassChars(class)
+		for i := 0; i < n; i++ {
+			if pos >= len(bban) || !bytes.Contains(chars, []byte{bban[pos]}) {
+				valid = false
+				return
+			}
+			pos++
+		}
+	})
+	if !valid || pos != len(bban) {
+		return false
+	}
+
+	return ibanMod97(bban+s[:4]) == 1
+}
@@ -0,0 +1,114 @@
+package buuid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestISBN13(t *testing.T) {
+	// known valid ISBNs
+	assert.True(t, ValidISBN13("9780306406157"))
+	assert.True(t, ValidISBN13("9791090636071"))
+
+	for i := 0; i < 100; i++ {
+		s := ISBN13()
+		assert.Equal(t, 13, len(s))
+		assert.True(t, ValidISBN13(s), s)
+
+		// changing any single digit breaks the check
+		pos := Int(0, 12)
+		b := []byte(s)
+		b[pos] = '0' + (b[pos]-'0'+byte(Int(1, 9)))%10
+		assert.False(t, ValidISBN13(string(b)), string(b))
+	}
+
+	assert.False(t, ValidISBN13(""))
+	assert.False(t, ValidISBN13("978030640615"))
+	assert.False(t, ValidISBN13("97803064061a7"))
+	assert.False(t, ValidISBN13("9770306406152"))
+}
+
+func TestEAN13(t *testing.T) {
+	// known valid EANs
+	assert.True(t, ValidEAN13("4006381333931"))
+	assert.True(t, ValidEAN13("5901234123457"))
+	assert.True(t, ValidEAN13("9780306406157"))
+
+	for i := 0; i < 100; i++ {
+		s := EAN13()
+		assert.Equal(t, 13, len(s))
+		assert.True(t, ValidEAN13(s), s)
+		assert.True(t, s[0] != '2' && s[0] != '9')
+
+		// changing any single digit breaks the check
+		pos := Int(0, 12)
+		b := []byte(s)
+		b[pos] = '0' + (b[pos]-'0'+byte(Int(1, 9)))%10
+		assert.False(t, ValidEAN13(string(b)), string(b))
+	}
+
+	assert.False(t, ValidEAN13(""))
+	assert.False(t, ValidEAN13("400638133393"))
+	assert.False(t, ValidEAN13("40063813339a1"))
+	assert.False(t, ValidEAN13("4006381333932"))
+}
+
+func TestIBAN(t *testing.T) {
+	// known valid IBANs
+	assert.True(t, ValidIBAN("DE89370400440532013000"))
+	assert.True(t, ValidIBAN("GB29 NWBK 6016 1331 9268 19"))
+	assert.True(t, ValidIBAN("FR1420041010050500013M02606"))
+	assert.True(t, ValidIBAN("NL91ABNA0417164300"))
+
+	lengths := map[string]int{"DE": 22, "GB": 22, "FR": 27, "IT": 27, "NL": 18, "NO": 15, "PL": 28, "CH": 21}
+	for country, l := range lengths {
+		for i := 0; i < 100; i++ {
+			s, err := IBAN(country)
+			assert.NoError(t, err)
+			assert.Equal(t, l, len(s))
+			assert.Equal(t, country, s[:2])
+			assert.True(t, ValidIBAN(s), s)
+
+			// changing any single digit breaks the check
+			b := []byte(s)
+			pos := Int(2, len(b)-1)
+			for b[pos] < '0' || b[pos] > '9' {
+				pos = Int(2, len(b)-1)
+			}
+			b[pos] = '0' + (b[pos]-'0'+byte(Int(1, 9)))%10
+			assert.False(t, ValidIBAN(string(b)), string(b))
+		}
+	}
+
+	s, err := IBAN("gb")
+	assert.NoError(t, err)
+	assert.Regexp(t, `^GB\d{2}[A-Z]{4}\d{14}$`, s)
+
+	_, err = IBAN("US")
+	assert.Error(t, err)
+
+	assert.False(t, ValidIBAN(""))
+	assert.False(t, ValidIBAN("DE8937040044053201300"))
+	assert.False(t, ValidIBAN("XX89370400440532013000"))
+	assert.False(t, ValidIBAN("GB29NWBK60161331926819X"))
+	assert.False(t, ValidIBAN("GB291WBK60161331926819"))
+}
+
+func BenchmarkISBN13(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ISBN13()
+	}
+}
+
+func BenchmarkEAN13(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		EAN13()
+	}
+}
+
+func BenchmarkIBAN(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = IBAN("DE")
+	}
+}
@@ -0,0 +1,55 @@
+package buuid
+
+import (
+	"fmt"
+	"math"
+)
+
+// PaletteColors generates n visually distinct colors as lowercase #rrggbb hex strings. Hues are spaced
+// evenly around the HSL wheel, 360/n degrees apart from a random starting hue, while saturation is
+// jittered within [0.6, 0.8] and lightness within [0.45, 0.6] so the palette does not look flat.
+// An empty slice is returned if n is not positive.
+// example: PaletteColors(3) returns [#d2493d #3dd25a #4e3dd2]
+func PaletteColors(n int) []string {
+	if n <= 0 {
+		return []string{}
+	}
+
+	colors := make([]string, n)
+	start := randFloat() * 360
+	for i := range colors {
+		h := math.Mod(start+float64(i)*360/float64(n), 360)
+		s := 0.6 + randFloat()*0.2
+		l := 0.45 + randFloat()*0.15
+		r, g, b := hslToRGB(h, s, l)
+		colors[i] = fmt.Sprintf("#%02x%02x%02x", r, g, b)
+	}
+
+	return colors
+}
+
+// hslToRGB converts a hue in degrees [0, 360) and saturation and lightness in [0, 1] to 8-bit RGB.
+func hslToRGB(h, s, l float64) (r, g, b uint8) {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var rf, gf, bf float64
+	switch {
+	case h < 60:
+		rf, gf, bf = c, x, 0
+	case h < 120:
+		rf, gf, bf = x, c, 0
+	case h < 180:
+		rf, gf, bf = 0, c, x
+	case h < 240:
+		rf, gf, bf = 0, x, c
+	case h < 300:
+		rf, gf, bf = x, 0, c
+	default:
+		rf, gf, bf = c, 0, x
+	}
+
+	to8 := func(v float64) uint8 { return uint8(math.Round((v + m) * 255)) }
+	return to8(rf), to8(gf), to8(bf)
+}
@@ -0,0 +1,66 @@
+package buuid
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testHue returns the HSL hue in degrees of a #rrggbb color.
+func testHue(hex string) float64 {
+	v, _ := strconv.ParseUint(hex[1:], 16, 32)
+	r, g, b := float64(v>>16&0xff)/255, float64(v>>8&0xff)/255, float64(v&0xff)/255
+	hi, lo := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	d := hi - lo
+
+	var h float64
+	switch hi {
+	case r:
+		h = math.Mod((g-b)/d, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return math.Mod(h*60+360, 360)
+}
+
+func TestPaletteColors(t *testing.T) {
+	assert.Equal(t, 0, len(PaletteColors(0)))
+
+	re := regexp.MustCompile(`^#[0-9a-f]{6}$`)
+	for _, n := range []int{1, 3, 8, 12} {
+		colors := PaletteColors(n)
+		assert.Equal(t, n, len(colors))
+
+		first := testHue(colors[0])
+		for i, c := range colors {
+			assert.Regexp(t, re, c)
+
+			// hue i sits i*360/n degrees after the first, up to 8-bit rounding
+			want := math.Mod(first+float64(i)*360/float64(n), 360)
+			diff := math.Abs(testHue(c) - want)
+			assert.True(t, math.Min(diff, 360-diff) < 2, "color %d %s hue %v want %v", i, c, testHue(c), want)
+		}
+	}
+}
+
+func TestHSLToRGB(t *testing.T) {
+	r, g, b := hslToRGB(0, 1, 0.5)
+	assert.Equal(t, []uint8{255, 0, 0}, []uint8{r, g, b})
+	r, g, b = hslToRGB(120, 1, 0.5)
+	assert.Equal(t, []uint8{0, 255, 0}, []uint8{r, g, b})
+	r, g, b = hslToRGB(240, 1, 0.25)
+	assert.Equal(t, []uint8{0, 0, 128}, []uint8{r, g, b})
+	r, g, b = hslToRGB(0, 0, 1)
+	assert.Equal(t, []uint8{255, 255, 255}, []uint8{r, g, b})
+}
+
+func BenchmarkPaletteColors_8(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		PaletteColors(8)
+	}
+}
@@ -0,0 +1,59 @@
+package buuid
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// cronFields are the value ranges of the 5 standard cron fields:
+// minute, hour, day of month, month and day of week.
+var cronFields = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+
+// CronExpr generates a random syntactically valid 5-field cron expression,
+// each field is one of *, a value, a range a-b, a step */n, a stepped range a-b/n or a list of values,
+// with every value inside the field's range.
+// example: "*/15 9-17 * 1,6 1-5"
+func CronExpr() string {
+	fields := make([]string, len(cronFields))
+	for i, r := range cronFields {
+		fields[i] = cronField(r[0], r[1])
+	}
+	return strings.Join(fields, " ")
+}
+
+func cronField(lo, hi int) string {
+	switch randIntn(6) {
+	case 0:
+		return "*"
+	case 1:
+		return strconv.Itoa(Int(lo, hi))
+	case 2:
+		a, b := cronRange(lo, hi)
+		return strconv.Itoa(a) + "-" + strconv.Itoa(b)
+	case 3:
+		return "*/" + strconv.Itoa(Int(1, hi-lo))
+	case 4:
+		a, b := cronRange(lo, hi)
+		return strconv.Itoa(a) + "-" + strconv.Itoa(b) + "/" + strconv.Itoa(Int(1, max(1, b-a)))
+	default:
+		all := make([]int, hi-lo+1)
+		for i := range all {
+			all[i] = lo + i
+		}
+		values, _ := Sample(all, Int(2, 4))
+		sort.Ints(values) // lists are conventionally ascending
+
+		parts := make([]string, len(values))
+		for i, v := range values {
+			parts[i] = strconv.Itoa(v)
+		}
+		return strings.Join(parts, ",")
+	}
+}
+
+// cronRange returns a random a < b inside [lo, hi].
+func cronRange(lo, hi int) (int, int) {
+	a := Int(lo, hi-1)
+	return a, Int(a+1, hi)
+}
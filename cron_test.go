@@ -0,0 +1,26 @@
+package buuid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronExpr(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		s := CronExpr()
+		fields := strings.Fields(s)
+		assert.Equal(t, 5, len(fields), s)
+
+		_, err := cron.ParseStandard(s)
+		assert.NoError(t, err, s)
+	}
+}
+
+func BenchmarkCronExpr(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		CronExpr()
+	}
+}
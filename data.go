@@ -0,0 +1,92 @@
+package buuid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// RepeatedPattern generates totalLen bytes by tiling a random pattern of patternLen bytes,
+// then mutating each byte independently with probability mutationRate: a mutated byte is replaced
+// by a fresh random byte (which may coincidentally equal the original). mutationRate is clamped to [0, 1],
+// an empty slice is returned if patternLen or totalLen is not positive.
+// example: RepeatedPattern(16, 4096, 0.01)
+func RepeatedPattern(patternLen, totalLen int, mutationRate float64) []byte {
+	if patternLen <= 0 || totalLen <= 0 {
+		return []byte{}
+	}
+	if mutationRate < 0 || math.IsNaN(mutationRate) {
+		mutationRate = 0
+	} else if mutationRate > 1 {
+		mutationRate = 1
+	}
+
+	pattern := make([]byte, patternLen)
+	readRandom(pattern)
+
+	result := make([]byte, totalLen)
+	for i := 0; i < totalLen; i += patternLen {
+		copy(result[i:], pattern)
+	}
+
+	if mutationRate > 0 {
+		var b [1]byte
+		for i := range result {
+			if randFloat() < mutationRate {
+				readRandom(b[:])
+				result[i] = b[0]
+			}
+		}
+	}
+
+	return result
+}
+
+// compressibleBlockSize is the block size CompressibleBytes interleaves random and repeated bytes at,
+// well below the 32 KiB window of DEFLATE so every block is compressed on its own merits.
+const compressibleBlockSize = 4096
+
+// CompressibleBytes generates size bytes that gzip compresses to roughly ratio times size.
+// Each block of compressibleBlockSize bytes starts with a random region, which does not compress,
+// and ends with zero bytes, which compress to almost nothing, the random regions total ratio*size bytes.
+// ratio is clamped to [0, 1], an empty slice is returned if size is not positive.
+// example: CompressibleBytes(1<<20, 0.3)
+func CompressibleBytes(size int, ratio float64) []byte {
+	if size <= 0 {
+		return []byte{}
+	}
+	if ratio < 0 || math.IsNaN(ratio) {
+		ratio = 0
+	} else if ratio > 1 {
+		ratio = 1
+	}
+
+	result := make([]byte, size)
+	random := 0
+	for i := 0; i < size; i += compressibleBlockSize {
+		end := min(i+compressibleBlockSize, size)
+		n := int(ratio*float64(end)) - random
+		readRandom(result[i : i+n])
+		random += n
+	}
+
+	return result
+}
+
+// AlignedBytes generates random characters of kind, see Bytes, whose length is the smallest multiple of
+// alignment that is at least minLen, for block-aligned I/O. An empty slice is returned if minLen is not
+// positive, alignment must be positive and an error is returned if the aligned length overflows int.
+// example: AlignedBytes(1000, 512, R_All) returns 1024 bytes
+func AlignedBytes(minLen, alignment, kind int) ([]byte, error) {
+	if alignment <= 0 {
+		return nil, errors.New("buuid: alignment must be positive")
+	}
+	if minLen <= 0 {
+		return []byte{}, nil
+	}
+	if minLen > math.MaxInt-(alignment-1) {
+		return nil, fmt.Errorf("buuid: %d aligned to %d overflows int", minLen, alignment)
+	}
+
+	return Bytes(kind, (minLen+alignment-1)/alignment*alignment), nil
+}
@@ -0,0 +1,98 @@
+package buuid
+
+import (
+	"bytes"
+	"compress/gzip"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepeatedPattern(t *testing.T) {
+	assert.Equal(t, 0, len(RepeatedPattern(0, 10, 0)))
+	assert.Equal(t, 0, len(RepeatedPattern(10, 0, 0)))
+
+	for _, c := range [][2]int{{1, 10}, {7, 100}, {16, 16}, {32, 10}} {
+		b := RepeatedPattern(c[0], c[1], 0)
+		assert.Equal(t, c[1], len(b))
+
+		pattern := b
+		if len(pattern) > c[0] {
+			pattern = pattern[:c[0]]
+		}
+		for i := 0; i < len(b); i += c[0] {
+			end := i + c[0]
+			if end > len(b) {
+				end = len(b)
+			}
+			assert.True(t, bytes.Equal(pattern[:end-i], b[i:end]))
+		}
+	}
+
+	// with a full mutation rate the tiling is almost surely broken
+	b := RepeatedPattern(8, 4096, 1)
+	assert.Equal(t, 4096, len(b))
+	assert.False(t, bytes.Equal(b[:8], b[8:16]) && bytes.Equal(b[:8], b[16:24]) && bytes.Equal(b[:8], b[24:32]))
+}
+
+func TestCompressibleBytes(t *testing.T) {
+	assert.Equal(t, 0, len(CompressibleBytes(0, 0.5)))
+	assert.Equal(t, 10, len(CompressibleBytes(10, 2)))
+
+	size := 1 << 20
+	for _, ratio := range []float64{0, 0.1, 0.25, 0.5, 0.75, 1} {
+		b := CompressibleBytes(size, ratio)
+		assert.Equal(t, size, len(b))
+
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		_, err := w.Write(b)
+		assert.NoError(t, err)
+		assert.NoError(t, w.Close())
+		assert.InDelta(t, ratio, float64(buf.Len())/float64(size), 0.02, "ratio %v", ratio)
+	}
+}
+
+func TestAlignedBytes(t *testing.T) {
+	cases := [][3]int{{1000, 512, 1024}, {1024, 512, 1024}, {1025, 512, 1536}, {1, 1, 1}, {7, 3, 9}, {5, 4096, 4096}}
+	for _, c := range cases {
+		b, err := AlignedBytes(c[0], c[1], R_NUM)
+		assert.NoError(t, err)
+		assert.Equal(t, c[2], len(b))
+		assert.Equal(t, 0, len(b)%c[1])
+		assert.True(t, len(b) >= c[0])
+		assert.True(t, isDigits(string(b)))
+	}
+
+	b, err := AlignedBytes(0, 512, R_All)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(b))
+
+	_, err = AlignedBytes(10, 0, R_All)
+	assert.Error(t, err)
+
+	// the aligned length overflows int
+	_, err = AlignedBytes(math.MaxInt-510, 512, R_All)
+	assert.Error(t, err)
+	_, err = AlignedBytes(math.MaxInt, 2, R_All)
+	assert.Error(t, err)
+}
+
+func BenchmarkRepeatedPattern(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		RepeatedPattern(16, 4096, 0.01)
+	}
+}
+
+func BenchmarkCompressibleBytes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		CompressibleBytes(4096, 0.5)
+	}
+}
+
+func BenchmarkAlignedBytes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = AlignedBytes(1000, 512, R_All)
+	}
+}
@@ -0,0 +1,85 @@
+package buuid
+
+import (
+	"fmt"
+)
+
+// ForeignKey declares a column whose values reference the primary keys of a previously added table.
+type ForeignKey struct {
+	Column   string
+	RefTable string
+}
+
+// Row is a generated record, ID is the primary key and Refs maps each foreign key column to a referenced ID.
+type Row struct {
+	ID   int64
+	Refs map[string]int64
+}
+
+// Dataset builds tables of generated records with referential integrity: primary keys are unique
+// NewID values and foreign keys only reference primary keys that already exist in the referenced table.
+// It is not safe for concurrent use.
+type Dataset struct {
+	tables map[string][]Row
+}
+
+// NewDataset creates an empty Dataset.
+func NewDataset() *Dataset {
+	return &Dataset{tables: make(map[string][]Row)}
+}
+
+// AddTable generates rows records for a new table named name, every foreign key must reference
+// a previously added table with at least one row.
+// example: ds.AddTable("orders", 100, ForeignKey{Column: "user_id", RefTable: "users"})
+func (d *Dataset) AddTable(name string, rows int, fks ...ForeignKey) error {
+	if _, ok := d.tables[name]; ok {
+		return fmt.Errorf("buuid: table %q already exists", name)
+	}
+	if rows < 0 {
+		return fmt.Errorf("buuid: table %q: rows must be non-negative", name)
+	}
+
+	columns := make(map[string]struct{}, len(fks))
+	for _, fk := range fks {
+		if _, ok := columns[fk.Column]; ok {
+			return fmt.Errorf("buuid: table %q: duplicate column %q", name, fk.Column)
+		}
+		columns[fk.Column] = struct{}{}
+
+		ref, ok := d.tables[fk.RefTable]
+		if !ok {
+			return fmt.Errorf("buuid: table %q: referenced table %q does not exist", name, fk.RefTable)
+		}
+		if len(ref) == 0 && rows > 0 {
+			return fmt.Errorf("buuid: table %q: referenced table %q is empty", name, fk.RefTable)
+		}
+	}
+
+	table := make([]Row, rows)
+	ids := make(map[int64]struct{}, rows)
+	for i := range table {
+		id := NewID()
+		for {
+			if _, ok := ids[id]; !ok {
+				break
+			}
+			id = NewID()
+		}
+		ids[id] = struct{}{}
+
+		refs := make(map[string]int64, len(fks))
+		for _, fk := range fks {
+			ref := d.tables[fk.RefTable]
+			refs[fk.Column] = ref[randIntn(len(ref))].ID
+		}
+		table[i] = Row{ID: id, Refs: refs}
+	}
+
+	d.tables[name] = table
+	return nil
+}
+
+// Rows returns the generated rows of table name, nil if the table does not exist.
+func (d *Dataset) Rows(name string) []Row {
+	return d.tables[name]
+}
@@ -0,0 +1,47 @@
+package buuid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataset(t *testing.T) {
+	ds := NewDataset()
+	assert.NoError(t, ds.AddTable("users", 50))
+	assert.NoError(t, ds.AddTable("products", 20))
+	assert.NoError(t, ds.AddTable("orders", 500,
+		ForeignKey{Column: "user_id", RefTable: "users"},
+		ForeignKey{Column: "product_id", RefTable: "products"},
+	))
+
+	keys := func(table string) map[int64]bool {
+		m := map[int64]bool{}
+		for _, r := range ds.Rows(table) {
+			m[r.ID] = true
+		}
+		return m
+	}
+	users, products, orders := keys("users"), keys("products"), keys("orders")
+	assert.Equal(t, 50, len(users))
+	assert.Equal(t, 20, len(products))
+	assert.Equal(t, 500, len(orders))
+
+	for _, r := range ds.Rows("orders") {
+		assert.Equal(t, 2, len(r.Refs))
+		assert.True(t, users[r.Refs["user_id"]])
+		assert.True(t, products[r.Refs["product_id"]])
+	}
+
+	assert.Nil(t, ds.Rows("missing"))
+	assert.Error(t, ds.AddTable("users", 1))
+	assert.Error(t, ds.AddTable("items", 1, ForeignKey{Column: "x", RefTable: "missing"}))
+	assert.Error(t, ds.AddTable("items", -1))
+	assert.Error(t, ds.AddTable("items", 1,
+		ForeignKey{Column: "x", RefTable: "users"},
+		ForeignKey{Column: "x", RefTable: "products"},
+	))
+
+	assert.NoError(t, ds.AddTable("empty", 0))
+	assert.Error(t, ds.AddTable("items", 1, ForeignKey{Column: "x", RefTable: "empty"}))
+}
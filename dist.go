@@ -0,0 +1,165 @@
+package buuid
+
+import (
+	"errors"
+	"math"
+)
+
+// Zipf generates a random integer in [1, n] following a Zipf distribution with exponent s,
+// P(k) is proportional to 1/k^s. It uses the rejection-inversion method of Hörmann and Derflinger,
+// s must be greater than 1 and n at least 1.
+func Zipf(n int, s float64) (int, error) {
+	if n < 1 {
+		return 0, errors.New("buuid: n must be at least 1")
+	}
+	if !(s > 1) || math.IsInf(s, 0) {
+		return 0, errors.New("buuid: s must be greater than 1")
+	}
+
+	// The distribution is sampled over k in [0, n-1] with P(k) proportional to (1+k)^-s.
+	oneMinusS := 1 - s
+	h := func(x float64) float64 {
+		return math.Exp(oneMinusS*math.Log(1+x)) / oneMinusS
+	}
+	hinv := func(x float64) float64 {
+		return math.Exp(math.Log(oneMinusS*x)/oneMinusS) - 1
+	}
+
+	hxm := h(float64(n-1) + 0.5)
+	hx0MinusHxm := h(0.5) - 1 - hxm
+	squeeze := 1 - hinv(h(1.5)-math.Exp(-s*math.Log(2)))
+
+	var k float64
+	for {
+		ur := hxm + randFloat()*hx0MinusHxm
+		x := hinv(ur)
+		k = math.Floor(x + 0.5)
+		if k-x <= squeeze {
+			break
+		}
+		if ur >= h(k+0.5)-math.Exp(-math.Log(k+1)*s) {
+			break
+		}
+	}
+
+	return int(k) + 1, nil
+}
+
+// GumbelMax returns a random index i with probability softmax(logits)[i], using the Gumbel-max trick:
+// argmax(logits[i] + G_i) where G_i are independent standard Gumbel noise. It never exponentiates
+// the logits, so it is numerically stable for large values. -Inf logits are never chosen,
+// an error is returned if every logit is -Inf.
+func GumbelMax(logits []float64) (int, error) {
+	if len(logits) == 0 {
+		return 0, errors.New("buuid: logits is empty")
+	}
+
+	best, bestScore := -1, math.Inf(-1)
+	for i, l := range logits {
+		if math.IsNaN(l) || math.IsInf(l, 1) {
+			return 0, errors.New("buuid: logits must not be NaN or +Inf")
+		}
+		if math.IsInf(l, -1) {
+			continue
+		}
+
+		u := randFloat()
+		for u == 0 {
+			u = randFloat()
+		}
+		score := l - math.Log(-math.Log(u))
+		if best < 0 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best < 0 {
+		return 0, errors.New("buuid: logits has no finite value")
+	}
+
+	return best, nil
+}
+
+// binomial returns a random number of successes in n trials with success probability p,
+// using inversion for a small mean and Hörmann's BTRS transformed rejection otherwise.
+func binomial(n int, p float64) int {
+	if n <= 0 || p <= 0 {
+		return 0
+	}
+	if p >= 1 {
+		return n
+	}
+	if p > 0.5 {
+		return n - binomial(n, 1-p)
+	}
+
+	q := 1 - p
+	if float64(n)*p < 10 {
+		// Inversion, walking the CDF from 0 with the pmf recurrence.
+		s := p / q
+		a := float64(n+1) * s
+		r := math.Pow(q, float64(n))
+		u := randFloat()
+		x := 0
+		for u > r && x < n {
+			u -= r
+			x++
+			r *= a/float64(x) - s
+		}
+		return x
+	}
+
+	fn := float64(n)
+	spq := math.Sqrt(fn * p * q)
+	b := 1.15 + 2.53*spq
+	a := -0.0873 + 0.0248*b + 0.01*p
+	c := fn*p + 0.5
+	vr := 0.92 - 4.2/b
+	r := p / q
+	alpha := (2.83 + 5.1/b) * spq
+	m := math.Floor((fn + 1) * p)
+
+	for {
+		u := randFloat() - 0.5
+		v := randFloat()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + c)
+		if k < 0 || k > fn {
+			continue
+		}
+		if us >= 0.07 && v <= vr {
+			return int(k)
+		}
+
+		v = math.Log(v * alpha / (a/(us*us) + b))
+		bound := (m+0.5)*math.Log((m+1)/(r*(fn-m+1))) +
+			(fn+1)*math.Log((fn-m+1)/(fn-k+1)) +
+			(k+0.5)*math.Log(r*(fn-k+1)/(k+1)) +
+			stirlingTail(m) + stirlingTail(fn-m) - stirlingTail(k) - stirlingTail(fn-k)
+		if v <= bound {
+			return int(k)
+		}
+	}
+}
+
+var stirlingTailTable = [10]float64{
+	0.08106146679532726, 0.04134069595540929, 0.02767792568499834, 0.02079067210376509, 0.01664469118982119,
+	0.01387612882307075, 0.01189670994589177, 0.01041126526197209, 0.009255462182712733, 0.008330563433362871,
+}
+
+// stirlingTail returns log(k!) minus its Stirling approximation.
+func stirlingTail(k float64) float64 {
+	if k <= 9 {
+		return stirlingTailTable[int(k)]
+	}
+	kp1sq := (k + 1) * (k + 1)
+	return (1.0/12 - (1.0/360-1.0/1260/kp1sq)/kp1sq) / (k + 1)
+}
+
+// randNorm returns a standard normally distributed random number, using the Box-Muller transform.
+func randNorm() float64 {
+	u := randFloat()
+	for u == 0 {
+		u = randFloat()
+	}
+	return math.Sqrt(-2*math.Log(u)) * math.Cos(2*math.Pi*randFloat())
+}
@@ -0,0 +1,122 @@
+package buuid
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZipf(t *testing.T) {
+	n, s := 10, 2.0
+	l := 100000
+	counts := make([]int, n+1)
+	for i := 0; i < l; i++ {
+		k, err := Zipf(n, s)
+		assert.NoError(t, err)
+		assert.True(t, k >= 1 && k <= n)
+		counts[k]++
+	}
+
+	// frequencies follow 1/k^s normalized over [1, n]
+	norm := 0.0
+	for k := 1; k <= n; k++ {
+		norm += 1 / math.Pow(float64(k), s)
+	}
+	for k := 1; k <= n; k++ {
+		assert.InDelta(t, 1/math.Pow(float64(k), s)/norm, float64(counts[k])/float64(l), 0.01, k)
+		if k > 1 {
+			assert.Greater(t, counts[1], counts[k])
+		}
+	}
+
+	k, err := Zipf(1, 1.5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, k)
+
+	_, err = Zipf(0, 2)
+	assert.Error(t, err)
+	_, err = Zipf(10, 1)
+	assert.Error(t, err)
+	_, err = Zipf(10, math.NaN())
+	assert.Error(t, err)
+}
+
+func TestGumbelMax(t *testing.T) {
+	logits := []float64{1, 2, 0.5, math.Inf(-1), 3}
+	softmax := make([]float64, len(logits))
+	sum := 0.0
+	for i, l := range logits {
+		softmax[i] = math.Exp(l)
+		sum += softmax[i]
+	}
+
+	l := 100000
+	counts := make([]int, len(logits))
+	for i := 0; i < l; i++ {
+		k, err := GumbelMax(logits)
+		assert.NoError(t, err)
+		counts[k]++
+	}
+	assert.Equal(t, 0, counts[3])
+	for i := range logits {
+		assert.InDelta(t, softmax[i]/sum, float64(counts[i])/float64(l), 0.01)
+	}
+
+	// large logits do not overflow
+	k, err := GumbelMax([]float64{1000, 1010})
+	assert.NoError(t, err)
+	assert.True(t, k == 0 || k == 1)
+
+	_, err = GumbelMax(nil)
+	assert.Error(t, err)
+	_, err = GumbelMax([]float64{1, math.NaN()})
+	assert.Error(t, err)
+	_, err = GumbelMax([]float64{math.Inf(-1), math.Inf(-1)})
+	assert.Error(t, err)
+
+	// a single finite logit is always chosen
+	k, err = GumbelMax([]float64{math.Inf(-1), -1e300, math.Inf(-1)})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, k)
+}
+
+func TestBinomial(t *testing.T) {
+	assert.Equal(t, 0, binomial(0, 0.5))
+	assert.Equal(t, 0, binomial(10, 0))
+	assert.Equal(t, 10, binomial(10, 1))
+
+	for _, c := range []struct {
+		n int
+		p float64
+	}{{10, 0.3}, {100, 0.05}, {1000, 0.5}, {100000, 0.01}, {50, 0.9}} {
+		l := 5000
+		sum, sumSq := 0.0, 0.0
+		for i := 0; i < l; i++ {
+			k := binomial(c.n, c.p)
+			assert.True(t, k >= 0 && k <= c.n)
+			sum += float64(k)
+			sumSq += float64(k) * float64(k)
+		}
+		mean := sum / float64(l)
+		variance := sumSq/float64(l) - mean*mean
+
+		wantMean := float64(c.n) * c.p
+		wantVar := wantMean * (1 - c.p)
+		assert.InDelta(t, wantMean, mean, 5*math.Sqrt(wantVar/float64(l)), c)
+		assert.InDelta(t, wantVar, variance, 0.1*wantVar+0.1, c)
+	}
+}
+
+func BenchmarkZipf(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = Zipf(1000, 1.2)
+	}
+}
+
+func BenchmarkGumbelMax(b *testing.B) {
+	logits := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	for i := 0; i < b.N; i++ {
+		_, _ = GumbelMax(logits)
+	}
+}
@@ -0,0 +1,56 @@
+package buuid
+
+import (
+	"errors"
+	"math"
+)
+
+// LengthForEntropy returns the minimum length of a random string over an alphabet of alphabetSize
+// characters that carries at least targetBits bits of entropy, ceil(targetBits / log2(alphabetSize)).
+// example: LengthForEntropy(62, 128) returns 22
+func LengthForEntropy(alphabetSize int, targetBits float64) (int, error) {
+	if alphabetSize < 2 {
+		return 0, errors.New("buuid: alphabetSize must be at least 2")
+	}
+	if math.IsNaN(targetBits) || math.IsInf(targetBits, 0) {
+		return 0, errors.New("buuid: targetBits must be finite")
+	}
+	if targetBits <= 0 {
+		return 0, nil
+	}
+
+	return int(math.Ceil(targetBits / math.Log2(float64(alphabetSize)))), nil
+}
+
+// MeasureBias draws samples bytes from gen and returns the largest absolute difference between the observed
+// frequency of a character and the uniform frequency 1/alphabetSize, characters of the alphabet that never
+// appeared count with frequency 0. A uniform generator scores close to 0, the sampling noise is about
+// sqrt(1/(alphabetSize*samples)), so compare results against that rather than against 0.
+// example: MeasureBias(func() byte { return String(R_NUM, 1)[0] }, 100000, 10)
+func MeasureBias(gen func() byte, samples int, alphabetSize int) (float64, error) {
+	if samples < 1 {
+		return 0, errors.New("buuid: samples must be positive")
+	}
+	if alphabetSize < 1 || alphabetSize > 256 {
+		return 0, errors.New("buuid: alphabetSize must be in [1, 256]")
+	}
+
+	var counts [256]int
+	for i := 0; i < samples; i++ {
+		counts[gen()]++
+	}
+
+	uniform := 1 / float64(alphabetSize)
+	bias, seen := 0.0, 0
+	for _, c := range counts {
+		if c > 0 {
+			seen++
+			bias = math.Max(bias, math.Abs(float64(c)/float64(samples)-uniform))
+		}
+	}
+	if seen < alphabetSize {
+		bias = math.Max(bias, uniform)
+	}
+
+	return bias, nil
+}
@@ -0,0 +1,60 @@
+package buuid
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLengthForEntropy(t *testing.T) {
+	cases := []struct {
+		size   int
+		bits   float64
+		length int
+	}{
+		{62, 128, 22},
+		{16, 128, 32},
+		{2, 64, 64},
+		{10, 20, 7},
+		{26, 1, 1},
+		{36, 0, 0},
+		{36, -5, 0},
+	}
+	for _, c := range cases {
+		n, err := LengthForEntropy(c.size, c.bits)
+		assert.NoError(t, err)
+		assert.Equal(t, c.length, n, c)
+	}
+
+	_, err := LengthForEntropy(1, 128)
+	assert.Error(t, err)
+	_, err = LengthForEntropy(62, math.NaN())
+	assert.Error(t, err)
+}
+
+func TestMeasureBias(t *testing.T) {
+	// crypto-backed digits are uniform up to sampling noise
+	bias, err := MeasureBias(func() byte { return String(R_NUM, 1)[0] }, 100000, 10)
+	assert.NoError(t, err)
+	assert.Less(t, bias, 0.005)
+
+	// a random byte modulo 200 hits 0~55 twice as often as 56~199
+	var b [1]byte
+	bias, err = MeasureBias(func() byte {
+		readRandom(b[:])
+		return b[0] % 200
+	}, 200000, 200)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.0/256-1.0/200, bias, 0.001)
+
+	// a constant generator misses every other character
+	bias, err = MeasureBias(func() byte { return 'a' }, 1000, 4)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.75, bias, 1e-9)
+
+	_, err = MeasureBias(func() byte { return 0 }, 0, 10)
+	assert.Error(t, err)
+	_, err = MeasureBias(func() byte { return 0 }, 10, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,241 @@
+package buuid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// fillTag holds the options parsed from a `buuid` struct tag.
+type fillTag struct {
+	skip     bool
+	length   int
+	kind     int
+	min, max int
+	dp       int
+}
+
+// FillStruct fills the exported string, bool, integer, float and time.Time fields of the struct
+// pointed to by ptr with random values, nested structs are filled recursively.
+// Fields of other types are skipped, or reported as an error if strict is true.
+//
+// Generation can be tuned with a `buuid` tag, options are comma separated:
+//
+//	len=16          string length, default 6
+//	kind=num|lower  string character set, any of num, upper, lower and all joined by |, default all
+//	min=1,max=9     inclusive range for integers and floats, default 0~100
+//	dp=2            decimal places for floats, default 2
+//	-               skip the field
+//
+// time.Time fields are set to a random time within the last year.
+// example: `buuid:"len=16,kind=lower"`
+func FillStruct(ptr any, strict ...bool) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("buuid: FillStruct requires a non-nil pointer to a struct")
+	}
+	if err := fillStruct(v.Elem(), len(strict) > 0 && strict[0]); err != nil {
+		return fmt.Errorf("buuid: %w", err)
+	}
+	return nil
+}
+
+func fillStruct(v reflect.Value, strict bool) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag, err := parseFillTag(sf.Tag.Get("buuid"))
+		if err != nil {
+			return fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		if tag.skip {
+			continue
+		}
+
+		if err := fillValue(v.Field(i), tag, strict); err != nil {
+			return fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+	}
+	return nil
+}
+
+func fillValue(f reflect.Value, tag fillTag, strict bool) error {
+	if f.Type() == timeType {
+		now := time.Now()
+		f.Set(reflect.ValueOf(RandomTime(now.AddDate(-1, 0, 0), now)))
+		return nil
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(String(tag.kind, tag.length))
+	case reflect.Bool:
+		f.SetBool(randIntn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if f.OverflowInt(int64(tag.min)) || f.OverflowInt(int64(tag.max)) {
+			return fmt.Errorf("range %d~%d overflows %s", tag.min, tag.max, f.Type())
+		}
+		f.SetInt(int64(Int(tag.min, tag.max)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if tag.min < 0 || f.OverflowUint(uint64(tag.max)) {
+			return fmt.Errorf("range %d~%d overflows %s", tag.min, tag.max, f.Type())
+		}
+		f.SetUint(uint64(Int(tag.min, tag.max)))
+	case reflect.Float32, reflect.Float64:
+		x := float64(tag.min)
+		if tag.max > tag.min {
+			x = Float64(tag.dp, tag.min, tag.max)
+		}
+		f.SetFloat(x)
+	case reflect.Struct:
+		return fillStruct(f, strict)
+	default:
+		if strict {
+			return fmt.Errorf("unsupported type %s", f.Type())
+		}
+	}
+	return nil
+}
+
+// FillJSON generates a JSON object for the struct type of v, a struct or a pointer to one, with random
+// values keyed by the fields' `json` tag names, v itself is not modified. Values follow the rules and
+// `buuid` tags of FillStruct, fields tagged json:"-" are skipped, omitempty fields are omitted half of
+// the time and embedded structs without a json name are flattened as encoding/json does.
+// The result unmarshals back into the struct type.
+// example: FillJSON(User{})
+func FillJSON(v any) ([]byte, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, errors.New("buuid: FillJSON requires a struct or a pointer to a struct")
+	}
+
+	obj := map[string]any{}
+	if err := fillJSON(reflect.New(t).Elem(), obj); err != nil {
+		return nil, fmt.Errorf("buuid: %w", err)
+	}
+	return json.Marshal(obj)
+}
+
+func fillJSON(v reflect.Value, obj map[string]any) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, opts, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		embedded := sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct
+		if !sf.IsExported() && !embedded {
+			continue
+		}
+
+		tag, err := parseFillTag(sf.Tag.Get("buuid"))
+		if err != nil {
+			return fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		if tag.skip {
+			continue
+		}
+
+		f := v.Field(i)
+		if embedded {
+			if err := fillJSON(f, obj); err != nil {
+				return fmt.Errorf("field %s: %w", sf.Name, err)
+			}
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if strings.Contains(","+opts+",", ",omitempty,") && randIntn(2) == 0 {
+			continue
+		}
+
+		if f.Kind() == reflect.Struct && f.Type() != timeType {
+			nested := map[string]any{}
+			if err := fillJSON(f, nested); err != nil {
+				return fmt.Errorf("field %s: %w", sf.Name, err)
+			}
+			obj[name] = nested
+			continue
+		}
+		if err := fillValue(f, tag, false); err != nil {
+			return fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		obj[name] = f.Interface()
+	}
+	return nil
+}
+
+func parseFillTag(s string) (fillTag, error) {
+	tag := fillTag{kind: R_All, max: 100, dp: 2}
+	if s == "" {
+		return tag, nil
+	}
+	if s == "-" {
+		tag.skip = true
+		return tag, nil
+	}
+
+	for _, opt := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(opt), "=")
+		if !ok {
+			return tag, fmt.Errorf("invalid tag option %q", opt)
+		}
+
+		switch key {
+		case "kind":
+			tag.kind = 0
+			for _, k := range strings.Split(value, "|") {
+				switch k {
+				case "num":
+					tag.kind |= R_NUM
+				case "upper":
+					tag.kind |= R_UPPER
+				case "lower":
+					tag.kind |= R_LOWER
+				case "all":
+					tag.kind |= R_All
+				default:
+					return tag, fmt.Errorf("invalid kind %q", k)
+				}
+			}
+		case "len", "min", "max", "dp":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return tag, fmt.Errorf("invalid %s %q", key, value)
+			}
+			switch key {
+			case "len":
+				tag.length = n
+			case "min":
+				tag.min = n
+			case "max":
+				tag.max = n
+			case "dp":
+				tag.dp = n
+			}
+		default:
+			return tag, fmt.Errorf("unknown tag option %q", key)
+		}
+	}
+
+	if tag.min > tag.max {
+		tag.min, tag.max = tag.max, tag.min
+	}
+
+	return tag, nil
+}
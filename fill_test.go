@@ -0,0 +1,140 @@
+package buuid
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fillAddress struct {
+	City string `buuid:"len=10,kind=upper"`
+	Zip  uint16 `buuid:"min=10000,max=60000"`
+}
+
+type fillUser struct {
+	Name      string
+	Code      string  `buuid:"len=16,kind=lower"`
+	Pin       string  `buuid:"len=4,kind=num"`
+	Age       int8    `buuid:"min=18,max=99"`
+	Score     float64 `buuid:"dp=1,min=-5,max=5"`
+	Active    bool
+	CreatedAt time.Time
+	Address   fillAddress
+	Ignored   string `buuid:"-"`
+	Tags      []string
+	secret    string
+}
+
+func TestFillStruct(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		var u fillUser
+		assert.NoError(t, FillStruct(&u))
+
+		assert.Equal(t, 6, len(u.Name))
+		assert.Regexp(t, regexp.MustCompile(`^[a-z]{16}$`), u.Code)
+		assert.Regexp(t, regexp.MustCompile(`^[0-9]{4}$`), u.Pin)
+		assert.True(t, u.Age >= 18 && u.Age <= 99)
+		assert.True(t, u.Score >= -5 && u.Score <= 5)
+		assert.False(t, u.CreatedAt.IsZero())
+		assert.False(t, u.CreatedAt.After(time.Now()))
+		assert.Regexp(t, regexp.MustCompile(`^[A-Z]{10}$`), u.Address.City)
+		assert.True(t, u.Address.Zip >= 10000 && u.Address.Zip <= 60000)
+		assert.Equal(t, "", u.Ignored)
+		assert.Nil(t, u.Tags)
+		assert.Equal(t, "", u.secret)
+	}
+
+	var u fillUser
+	assert.Error(t, FillStruct(&u, true))
+	assert.Error(t, FillStruct(u))
+	assert.Error(t, FillStruct((*fillUser)(nil)))
+
+	var overflow struct {
+		N int8 `buuid:"min=0,max=1000"`
+	}
+	assert.Error(t, FillStruct(&overflow))
+
+	var negative struct {
+		N uint `buuid:"min=-1,max=10"`
+	}
+	assert.Error(t, FillStruct(&negative))
+
+	var invalid struct {
+		S string `buuid:"kind=emoji"`
+	}
+	assert.Error(t, FillStruct(&invalid))
+}
+
+type fillBase struct {
+	ID string `json:"id" buuid:"len=8,kind=num"`
+}
+
+type fillJSONUser struct {
+	fillBase
+	Name      string      `json:"name" buuid:"len=12,kind=lower"`
+	Nickname  string      `json:"nickname,omitempty"`
+	Age       int         `json:"age" buuid:"min=18,max=99"`
+	Score     float64     `json:"score"`
+	CreatedAt time.Time   `json:"created_at"`
+	Address   fillAddress `json:"address"`
+	Ignored   string      `json:"-"`
+	Untagged  bool
+}
+
+func TestFillJSON(t *testing.T) {
+	omitted := 0
+	for i := 0; i < 200; i++ {
+		b, err := FillJSON(fillJSONUser{})
+		assert.NoError(t, err)
+
+		var obj map[string]any
+		assert.NoError(t, json.Unmarshal(b, &obj))
+		assert.Contains(t, obj, "id")
+		assert.Contains(t, obj, "created_at")
+		assert.Contains(t, obj, "Untagged")
+		assert.NotContains(t, obj, "Ignored")
+		assert.NotContains(t, obj, "fillBase")
+		if _, ok := obj["nickname"]; !ok {
+			omitted++
+		}
+
+		var u fillJSONUser
+		assert.NoError(t, json.Unmarshal(b, &u))
+		assert.Regexp(t, regexp.MustCompile(`^[0-9]{8}$`), u.ID)
+		assert.Regexp(t, regexp.MustCompile(`^[a-z]{12}$`), u.Name)
+		assert.True(t, u.Age >= 18 && u.Age <= 99)
+		assert.False(t, u.CreatedAt.IsZero())
+		assert.Regexp(t, regexp.MustCompile(`^[A-Z]{10}$`), u.Address.City)
+		assert.True(t, u.Address.Zip >= 10000 && u.Address.Zip <= 60000)
+	}
+	assert.True(t, omitted > 50 && omitted < 150)
+
+	_, err := FillJSON(&fillJSONUser{})
+	assert.NoError(t, err)
+	_, err = FillJSON(42)
+	assert.Error(t, err)
+	_, err = FillJSON(nil)
+	assert.Error(t, err)
+
+	var invalid struct {
+		S string `json:"s" buuid:"kind=emoji"`
+	}
+	_, err = FillJSON(invalid)
+	assert.Error(t, err)
+}
+
+func BenchmarkFillStruct(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var u fillUser
+		_ = FillStruct(&u)
+	}
+}
+
+func BenchmarkFillJSON(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = FillJSON(fillJSONUser{})
+	}
+}
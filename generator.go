@@ -0,0 +1,377 @@
+package buuid
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"runtime"
+	"sync"
+)
+
+// Generator generates random values from an entropy source, the package level
+// String, Bytes, Int and Float64 functions use a Generator backed by crypto/rand.
+// It is safe for concurrent use if its source is.
+type Generator struct {
+	r        io.Reader
+	fallback *lockedRandSource
+}
+
+// NewGenerator creates a Generator that reads entropy from r, crypto/rand.Reader is used if r is nil.
+// If reading from r fails, values fall back to crypto/rand, then to the current time.
+func NewGenerator(r io.Reader) *Generator {
+	if r == nil {
+		r = rand.Reader
+	}
+	return &Generator{r: r, fallback: defaultRand}
+}
+
+// NewGeneratorWithFallback creates a Generator that reads from r for both the primary and the fallback
+// entropy, crypto/rand.Reader is used if r is nil. No value comes from crypto/rand unless r is nil,
+// if r also fails the fallback uses the current time.
+// It reduces security and is intended for tests that need to drive the fallback path,
+// for example with a reader that always fails.
+func NewGeneratorWithFallback(r io.Reader) *Generator {
+	if r == nil {
+		r = rand.Reader
+	}
+	return &Generator{r: r, fallback: &lockedRandSource{r: r}}
+}
+
+// NewFastGenerator creates a Generator backed by the xoshiro256** PRNG seeded with seed,
+// the same seed always produces the same sequence of values.
+// It is NOT cryptographically secure, use it only for benchmarks and simulations
+// where crypto/rand overhead dominates, never for tokens, IDs or secrets.
+func NewFastGenerator(seed uint64) *Generator {
+	return NewGenerator(newFastSource(seed))
+}
+
+// fastStateSize is the length of a NewFastGenerator state as returned by Snapshot.
+const fastStateSize = 32
+
+// Snapshot returns the current PRNG state of a Generator created by NewFastGenerator, so the values
+// that follow can be replayed later with Restore, for example to reproduce a failing fuzz case.
+// It returns nil for any other Generator, whose entropy cannot be replayed.
+func (g *Generator) Snapshot() []byte {
+	f, ok := g.r.(*fastSource)
+	if !ok {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	state := make([]byte, fastStateSize)
+	for i, v := range f.s {
+		binary.LittleEndian.PutUint64(state[i*8:], v)
+	}
+	return state
+}
+
+// Restore sets the PRNG state of a Generator created by NewFastGenerator to a state returned by Snapshot,
+// the Generator then produces the same values as it did after the snapshot.
+// An error is returned for any other Generator and for an invalid state.
+func (g *Generator) Restore(state []byte) error {
+	f, ok := g.r.(*fastSource)
+	if !ok {
+		return errors.New("buuid: only a NewFastGenerator generator can be restored")
+	}
+	if len(state) != fastStateSize {
+		return fmt.Errorf("buuid: state must be %d bytes", fastStateSize)
+	}
+
+	var s [4]uint64
+	for i := range s {
+		s[i] = binary.LittleEndian.Uint64(state[i*8:])
+	}
+	if s == [4]uint64{} {
+		return errors.New("buuid: the all-zero state is invalid")
+	}
+
+	f.mu.Lock()
+	f.s = s
+	f.mu.Unlock()
+	return nil
+}
+
+// String generates random strings of any length of multiple types, default length is 6 if size is empty,
+// see the package level String.
+func (g *Generator) String(kind int, size ...int) string {
+	return string(g.Bytes(kind, size...))
+}
+
+// Bytes generates random strings of any length of multiple types, default length is 6 if bytesLen is empty,
+// see the package level Bytes.
+func (g *Generator) Bytes(kind int, bytesLen ...int) []byte {
+	length := 6 // default length 6
+	if len(bytesLen) > 0 && bytesLen[0] > 0 {
+		length = bytesLen[0]
+	}
+
+	chars := kindChars(kind)
+
+	result := make([]byte, length)
+	for i := range result {
+		result[i] = chars[g.intn(len(chars))]
+	}
+
+	return result
+}
+
+// Int generates random numbers of specified range size, min<=random number<=max,
+// a negative single max gives [max, 0], see the package level Int.
+func (g *Generator) Int(rangeSize ...int) int {
+	var min, max int
+
+	switch len(rangeSize) {
+	case 0:
+		min, max = 0, 100 // default 0~100
+	case 1:
+		min, max = 0, rangeSize[0]
+		if max < 0 {
+			min, max = max, 0
+		}
+	default:
+		if rangeSize[0] > rangeSize[1] {
+			min, max = rangeSize[1], rangeSize[0]
+		} else {
+			min, max = rangeSize[0], rangeSize[1]
+		}
+	}
+
+	span, _ := rejectLimit(min, max)
+	if span == 0 {
+		var b [8]byte
+		g.read(b[:])
+		return min + int(binary.BigEndian.Uint64(b[:]))
+	}
+	return min + int(g.uint64n(span))
+}
+
+// Float64 generates a random floating point number of the specified range size, min<=random numbers<=max,
+// see the package level Float64.
+func (g *Generator) Float64(dpLength int, rangeSize ...int) float64 {
+	var min, max int
+
+	switch len(rangeSize) {
+	case 0:
+		min, max = 0, 100 // default 0~100
+	case 1:
+		min, max = 0, rangeSize[0]
+	default:
+		if rangeSize[0] > rangeSize[1] {
+			min, max = rangeSize[1], rangeSize[0]
+		} else {
+			min, max = rangeSize[0], rangeSize[1]
+		}
+	}
+
+	// Generate decimal part
+	dp := 0.0
+	if dpLength > 0 {
+		dpmax := big.NewInt(10)
+		dpmax.Exp(dpmax, big.NewInt(int64(dpLength)), nil)
+		n, err := rand.Int(g.r, dpmax)
+		if err != nil {
+			n = big.NewInt(g.fallback.Int63() % dpmax.Int64())
+		}
+		dp = float64(n.Int64()) / float64(dpmax.Int64())
+	}
+
+	// Generate integer part
+	intPart, err := rand.Int(g.r, big.NewInt(int64(max-min)))
+	if err != nil {
+		intPart = big.NewInt(g.fallback.Int63() % int64(max-min))
+	}
+
+	return float64(min) + float64(intPart.Int64()) + dp
+}
+
+// read fills b with random bytes, using the fallback source if the primary fails.
+func (g *Generator) read(b []byte) {
+	_, err := io.ReadFull(g.r, b)
+	if err != nil {
+		for i := range b {
+			b[i] = byte(g.fallback.Int63())
+		}
+	}
+}
+
+// intn returns a random number in [0, n), n must be greater than 0.
+func (g *Generator) intn(n int) int {
+	return int(g.uint64n(uint64(n)))
+}
+
+// uint64n returns a random number in [0, n), n must be greater than 0.
+func (g *Generator) uint64n(n uint64) uint64 {
+	// Reject the values above the largest multiple of n to keep the result unbiased.
+	limit := math.MaxUint64 - math.MaxUint64%n
+	var b [8]byte
+	for {
+		g.read(b[:])
+		v := binary.BigEndian.Uint64(b[:])
+		if v < limit {
+			return v % n
+		}
+	}
+}
+
+// rejectLimit returns the number of values in [min, max] as span, and limit, the largest multiple of span
+// in uint64, values at or above limit are rejected to keep v%span unbiased. span is computed in uint64
+// as max-min can overflow int, it is 0 when the range covers every uint64, then no value is rejected.
+func rejectLimit(min, max int) (span, limit uint64) {
+	span = uint64(max) - uint64(min) + 1
+	if span == 0 {
+		return 0, math.MaxUint64
+	}
+	return span, math.MaxUint64 - math.MaxUint64%span
+}
+
+// float returns a random floating point number in [0, 1) with 53 bits of precision.
+func (g *Generator) float() float64 {
+	var b [8]byte
+	g.read(b[:])
+	return float64(binary.BigEndian.Uint64(b[:])>>11) / (1 << 53)
+}
+
+// fastSource is an io.Reader producing the xoshiro256** stream, guarded by a mutex.
+type fastSource struct {
+	mu sync.Mutex
+	s  [4]uint64
+}
+
+func newFastSource(seed uint64) *fastSource {
+	// Expand the seed with splitmix64 so that similar seeds give unrelated states.
+	f := &fastSource{}
+	for i := range f.s {
+		seed += 0x9e3779b97f4a7c15
+		z := seed
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		f.s[i] = z ^ (z >> 31)
+	}
+	return f
+}
+
+func (f *fastSource) next() uint64 {
+	s := &f.s
+	result := rotl(s[1]*5, 7) * 9
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = rotl(s[3], 45)
+	return result
+}
+
+func rotl(x uint64, k uint) uint64 {
+	return (x << k) | (x >> (64 - k))
+}
+
+// Read fills p with the next bytes of the stream, it never fails.
+func (f *fastSource) Read(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var buf [8]byte
+	for i := 0; i < len(p); i += 8 {
+		binary.LittleEndian.PutUint64(buf[:], f.next())
+		copy(p[i:], buf[:])
+	}
+	return len(p), nil
+}
+
+// XORReader combines several entropy sources by XORing their output, so the result is
+// at least as unpredictable as the best working source. It is safe for concurrent use.
+type XORReader struct {
+	mu      sync.Mutex
+	readers []io.Reader
+	failed  []bool
+	errs    []error
+}
+
+// MultiReader returns an XORReader over readers, to be passed to NewGenerator.
+// A reader that fails is recorded and skipped from then on, reads fail only once every reader has failed.
+// example: NewGenerator(MultiReader(rand.Reader, hwReader))
+func MultiReader(readers ...io.Reader) *XORReader {
+	return &XORReader{
+		readers: append([]io.Reader(nil), readers...),
+		failed:  make([]bool, len(readers)),
+	}
+}
+
+// Read fills p with the XOR of len(p) bytes from every working reader.
+func (x *XORReader) Read(p []byte) (int, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	clear(p)
+	buf := make([]byte, len(p))
+	ok := false
+	for i, r := range x.readers {
+		if x.failed[i] {
+			continue
+		}
+		if _, err := io.ReadFull(r, buf); err != nil {
+			x.failed[i] = true
+			x.errs = append(x.errs, err)
+			continue
+		}
+		for j := range p {
+			p[j] ^= buf[j]
+		}
+		ok = true
+	}
+
+	if !ok {
+		return 0, errors.Join(append([]error{errors.New("buuid: all entropy sources failed")}, x.errs...)...)
+	}
+	return len(p), nil
+}
+
+// Errors returns the errors of the readers that have failed so far.
+func (x *XORReader) Errors() []error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return append([]error(nil), x.errs...)
+}
+
+// generateBufferSize is the size of the per-worker entropy buffer of GenerateN.
+const generateBufferSize = 4096
+
+// GenerateN calls fn n times across GOMAXPROCS workers and returns the results in index order.
+// Each worker passes fn its own Generator reading crypto/rand through a private buffer,
+// so workers neither share a source nor pay a system call per value, fn should draw from g
+// rather than the package level functions to benefit from it.
+// example: GenerateN(1000000, func(g *Generator) string { return g.String(R_All, 16) })
+func GenerateN(n int, fn func(g *Generator) string) []string {
+	if n <= 0 {
+		return []string{}
+	}
+
+	results := make([]string, n)
+	workers := min(runtime.GOMAXPROCS(0), n)
+	chunk := (n + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += chunk {
+		end := min(start+chunk, n)
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			g := NewGenerator(bufio.NewReaderSize(rand.Reader, generateBufferSize))
+			for i := start; i < end; i++ {
+				results[i] = fn(g)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	return results
+}
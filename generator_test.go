@@ -0,0 +1,218 @@
+package buuid
+
+import (
+	"bytes"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGenerator(t *testing.T) {
+	g := NewGenerator(nil)
+	for i := 0; i < 100; i++ {
+		n := g.Int(10, 20)
+		assert.True(t, n >= 10 && n <= 20)
+
+		f := g.Float64(2, 10, 20)
+		assert.True(t, f >= 10 && f <= 20)
+	}
+	assert.Equal(t, 6, len(g.String(R_All)))
+	assert.Equal(t, 32, len(g.Bytes(R_NUM, 32)))
+}
+
+func TestNewFastGenerator(t *testing.T) {
+	g1, g2 := NewFastGenerator(42), NewFastGenerator(42)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, g1.Int(), g2.Int())
+		assert.Equal(t, g1.Int(-1000, 1000), g2.Int(-1000, 1000))
+		assert.Equal(t, g1.Float64(3, 5, 50), g2.Float64(3, 5, 50))
+		assert.Equal(t, g1.String(R_All, 16), g2.String(R_All, 16))
+		assert.Equal(t, g1.Bytes(R_NUM|R_LOWER), g2.Bytes(R_NUM|R_LOWER))
+	}
+
+	g3 := NewFastGenerator(43)
+	assert.NotEqual(t, NewFastGenerator(42).String(R_All, 32), g3.String(R_All, 32))
+
+	g := NewFastGenerator(7)
+	for i := 0; i < 1000; i++ {
+		n := g.Int()
+		assert.True(t, n >= 0 && n <= 100)
+
+		n = g.Int(20, 10)
+		assert.True(t, n >= 10 && n <= 20)
+
+		f := g.Float64(2, 10, 20)
+		assert.True(t, f >= 10 && f <= 20)
+
+		for _, c := range g.Bytes(R_UPPER, 8) {
+			assert.True(t, c >= 'A' && c <= 'Z')
+		}
+	}
+}
+
+// countingReader counts reads and serves bytes from data, failing with io.EOF once it is exhausted.
+type countingReader struct {
+	reads int
+	data  []byte
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	r.reads++
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestGenerator_Snapshot(t *testing.T) {
+	g := NewFastGenerator(7)
+	g.String(R_All, 13)
+
+	state := g.Snapshot()
+	assert.Equal(t, fastStateSize, len(state))
+	want := []any{g.String(R_All, 16), g.Int(1, 1000), g.Float64(3, 10), g.Bytes(R_NUM, 5)}
+
+	// a restored generator, the same or a fresh one, replays the sequence exactly
+	for _, r := range []*Generator{g, NewFastGenerator(99)} {
+		assert.NoError(t, r.Restore(state))
+		got := []any{r.String(R_All, 16), r.Int(1, 1000), r.Float64(3, 10), r.Bytes(R_NUM, 5)}
+		assert.Equal(t, want, got)
+	}
+
+	// the snapshot is a copy, later draws do not change it
+	before := append([]byte(nil), state...)
+	g.Int()
+	assert.Equal(t, before, state)
+
+	assert.Nil(t, NewGenerator(nil).Snapshot())
+	assert.Error(t, NewGenerator(nil).Restore(state))
+	assert.Error(t, g.Restore(state[:16]))
+	assert.Error(t, g.Restore(make([]byte, fastStateSize)))
+}
+
+func TestNewGeneratorWithFallback(t *testing.T) {
+	// an empty reader fails the primary read, so every value comes from the fallback,
+	// which reads from the same failing reader before falling back to the current time
+	r := &countingReader{}
+	g := NewGeneratorWithFallback(r)
+
+	for i := 0; i < 100; i++ {
+		n := g.Int(10, 20)
+		assert.True(t, n >= 10 && n <= 20)
+
+		f := g.Float64(2, 10, 20)
+		assert.True(t, f >= 10 && f <= 20)
+
+		for _, c := range g.Bytes(R_LOWER, 8) {
+			assert.True(t, c >= 'a' && c <= 'z')
+		}
+	}
+	reads := r.reads
+	g.Int()
+	assert.Greater(t, r.reads, reads+1)
+
+	// a deterministic reader drives the primary path predictably
+	data := make([]byte, 8)
+	data[7] = 5
+	g = NewGeneratorWithFallback(&countingReader{data: data})
+	assert.Equal(t, 15, g.Int(10, 20))
+}
+
+func TestMultiReader(t *testing.T) {
+	a := bytes.Repeat([]byte{0x0f, 0xaa}, 8)
+	b := bytes.Repeat([]byte{0xf0, 0x55}, 8)
+	c := bytes.Repeat([]byte{0x01, 0x02}, 8)
+
+	r := MultiReader(bytes.NewReader(a), bytes.NewReader(b), bytes.NewReader(c))
+	p := make([]byte, 10)
+	n, err := r.Read(p)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, n)
+	for i := range p {
+		assert.Equal(t, a[i]^b[i]^c[i], p[i])
+	}
+	assert.Equal(t, 0, len(r.Errors()))
+
+	// c is exhausted by the next read and skipped, a and b still have 6 bytes
+	p = make([]byte, 6)
+	r = MultiReader(bytes.NewReader(a[:6]), bytes.NewReader(b[:6]), &countingReader{})
+	n, err = r.Read(p)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, n)
+	for i := range p {
+		assert.Equal(t, a[i]^b[i], p[i])
+	}
+	assert.Equal(t, 1, len(r.Errors()))
+
+	// every source failed
+	_, err = r.Read(p)
+	assert.Error(t, err)
+	assert.Equal(t, 3, len(r.Errors()))
+
+	g := NewGenerator(MultiReader(NewFastGenerator(1).r, NewFastGenerator(2).r))
+	for i := 0; i < 100; i++ {
+		n := g.Int(10, 20)
+		assert.True(t, n >= 10 && n <= 20)
+	}
+}
+
+func TestGenerateN(t *testing.T) {
+	assert.Equal(t, 0, len(GenerateN(0, func(g *Generator) string { return "" })))
+
+	for _, n := range []int{1, 7, 10000} {
+		var calls atomic.Int64
+		ids := GenerateN(n, func(g *Generator) string {
+			calls.Add(1)
+			return g.String(R_All, 16)
+		})
+		assert.Equal(t, n, len(ids))
+		assert.Equal(t, int64(n), calls.Load())
+
+		seen := make(map[string]struct{}, n)
+		for _, id := range ids {
+			assert.Equal(t, 16, len(id))
+			seen[id] = struct{}{}
+		}
+		assert.Equal(t, n, len(seen))
+	}
+}
+
+func BenchmarkGenerateN_10000(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		GenerateN(10000, func(g *Generator) string { return g.String(R_All, 16) })
+	}
+}
+
+func BenchmarkGenerateN_Sequential_10000(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ids := make([]string, 10000)
+		for j := range ids {
+			ids[j] = String(R_All, 16)
+		}
+	}
+}
+
+func BenchmarkGenerator_String_ALL_16(b *testing.B) {
+	g := NewGenerator(nil)
+	for i := 0; i < b.N; i++ {
+		g.String(R_All, 16)
+	}
+}
+
+func BenchmarkFastGenerator_String_ALL_16(b *testing.B) {
+	g := NewFastGenerator(1)
+	for i := 0; i < b.N; i++ {
+		g.String(R_All, 16)
+	}
+}
+
+func BenchmarkFastGenerator_Int(b *testing.B) {
+	g := NewFastGenerator(1)
+	for i := 0; i < b.N; i++ {
+		g.Int()
+	}
+}
@@ -2,7 +2,12 @@ module buuid
 
 go 1.23.4
 
-require github.com/stretchr/testify v1.10.0
+require (
+	github.com/Masterminds/semver/v3 v3.5.0
+	github.com/rivo/uniseg v0.4.7
+	github.com/robfig/cron/v3 v3.0.1
+	github.com/stretchr/testify v1.10.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
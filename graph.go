@@ -0,0 +1,38 @@
+package buuid
+
+import "errors"
+
+// RandomGraph generates the adjacency matrix of a random undirected weighted graph on nodes nodes,
+// an Erdős–Rényi G(n, p) graph: each pair of distinct nodes is joined with probability edgeProb
+// by an edge of random weight in [1, maxWeight]. The matrix is symmetric, 0 means no edge and
+// the diagonal is 0 as there are no self-loops. nodes must be non-negative, edgeProb in [0, 1]
+// and maxWeight at least 1.
+// example: RandomGraph(10, 0.3, 100)
+func RandomGraph(nodes int, edgeProb float64, maxWeight int) ([][]int, error) {
+	if nodes < 0 {
+		return nil, errors.New("buuid: nodes must be non-negative")
+	}
+	if !(edgeProb >= 0 && edgeProb <= 1) {
+		return nil, errors.New("buuid: edgeProb must be in [0, 1]")
+	}
+	if maxWeight < 1 {
+		return nil, errors.New("buuid: maxWeight must be at least 1")
+	}
+
+	cells := make([]int, nodes*nodes)
+	adj := make([][]int, nodes)
+	for i := range adj {
+		adj[i] = cells[i*nodes : (i+1)*nodes]
+	}
+
+	for i := 0; i < nodes; i++ {
+		for j := i + 1; j < nodes; j++ {
+			if randFloat() < edgeProb {
+				w := 1 + randIntn(maxWeight)
+				adj[i][j], adj[j][i] = w, w
+			}
+		}
+	}
+
+	return adj, nil
+}
@@ -0,0 +1,56 @@
+package buuid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomGraph(t *testing.T) {
+	n := 200
+	adj, err := RandomGraph(n, 0.3, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, n, len(adj))
+
+	edges := 0
+	for i := range adj {
+		assert.Equal(t, n, len(adj[i]))
+		assert.Equal(t, 0, adj[i][i])
+		for j := range adj[i] {
+			assert.Equal(t, adj[i][j], adj[j][i])
+			assert.True(t, adj[i][j] >= 0 && adj[i][j] <= 10)
+			if j > i && adj[i][j] > 0 {
+				edges++
+			}
+		}
+	}
+	pairs := n * (n - 1) / 2
+	assert.InDelta(t, 0.3, float64(edges)/float64(pairs), 0.02)
+
+	adj, err = RandomGraph(5, 1, 1)
+	assert.NoError(t, err)
+	for i := range adj {
+		for j := range adj[i] {
+			if i != j {
+				assert.Equal(t, 1, adj[i][j])
+			}
+		}
+	}
+
+	adj, err = RandomGraph(0, 0.5, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(adj))
+
+	_, err = RandomGraph(-1, 0.5, 1)
+	assert.Error(t, err)
+	_, err = RandomGraph(5, 1.5, 1)
+	assert.Error(t, err)
+	_, err = RandomGraph(5, 0.5, 0)
+	assert.Error(t, err)
+}
+
+func BenchmarkRandomGraph_100(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = RandomGraph(100, 0.1, 100)
+	}
+}
@@ -0,0 +1,38 @@
+package buuid
+
+import "errors"
+
+// ClusteredGrid generates an h by w grid, indexed grid[y][x], of cluster numbers in [0, clusters):
+// clusters seed cells are placed at distinct random positions and every cell takes the number of its
+// nearest seed by squared Euclidean distance, ties going to the lower number, a Voronoi diagram.
+// Every cluster owns at least its seed cell, so all numbers appear and form contiguous regions.
+// w and h must be positive and clusters in [1, w*h].
+// example: ClusteredGrid(64, 32, 5)
+func ClusteredGrid(w, h, clusters int) ([][]int, error) {
+	if w < 1 || h < 1 {
+		return nil, errors.New("buuid: w and h must be positive")
+	}
+	if clusters < 1 || clusters > w*h {
+		return nil, errors.New("buuid: clusters must be in [1, w*h]")
+	}
+
+	seeds := sortedDistinctInts(w*h, clusters)
+
+	cells := make([]int, w*h)
+	grid := make([][]int, h)
+	for y := range grid {
+		grid[y] = cells[y*w : (y+1)*w]
+		for x := range grid[y] {
+			best, bestDist := 0, -1
+			for i, s := range seeds {
+				dx, dy := x-s%w, y-s/w
+				if d := dx*dx + dy*dy; bestDist < 0 || d < bestDist {
+					best, bestDist = i, d
+				}
+			}
+			grid[y][x] = best
+		}
+	}
+
+	return grid, nil
+}
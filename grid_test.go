@@ -0,0 +1,68 @@
+package buuid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusteredGrid(t *testing.T) {
+	w, h, clusters := 40, 25, 6
+	for i := 0; i < 20; i++ {
+		grid, err := ClusteredGrid(w, h, clusters)
+		assert.NoError(t, err)
+		assert.Equal(t, h, len(grid))
+
+		counts := map[int]int{}
+		for _, row := range grid {
+			assert.Equal(t, w, len(row))
+			for _, v := range row {
+				assert.True(t, v >= 0 && v < clusters)
+				counts[v]++
+			}
+		}
+		assert.Equal(t, clusters, len(counts))
+
+		// clustered, not noise: most cells share their right neighbor's cluster
+		same, pairs := 0, 0
+		for _, row := range grid {
+			for x := 1; x < w; x++ {
+				pairs++
+				if row[x] == row[x-1] {
+					same++
+				}
+			}
+		}
+		assert.Greater(t, float64(same)/float64(pairs), 0.8)
+	}
+
+	// one cluster per cell
+	grid, err := ClusteredGrid(3, 2, 6)
+	assert.NoError(t, err)
+	seen := map[int]bool{}
+	for _, row := range grid {
+		for _, v := range row {
+			seen[v] = true
+		}
+	}
+	assert.Equal(t, 6, len(seen))
+
+	grid, err = ClusteredGrid(4, 4, 1)
+	assert.NoError(t, err)
+	for _, row := range grid {
+		assert.Equal(t, []int{0, 0, 0, 0}, row)
+	}
+
+	_, err = ClusteredGrid(0, 4, 1)
+	assert.Error(t, err)
+	_, err = ClusteredGrid(4, 4, 0)
+	assert.Error(t, err)
+	_, err = ClusteredGrid(4, 4, 17)
+	assert.Error(t, err)
+}
+
+func BenchmarkClusteredGrid(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = ClusteredGrid(64, 64, 8)
+	}
+}
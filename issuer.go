@@ -0,0 +1,55 @@
+package buuid
+
+import (
+	"errors"
+	"sync"
+)
+
+// maxIssueRetries bounds how many times UniqueIssuer regenerates a colliding value.
+const maxIssueRetries = 1024
+
+// ErrIssuerExhausted is returned when UniqueIssuer cannot find an unused value within its retry bound.
+var ErrIssuerExhausted = errors.New("buuid: unique issuer exhausted")
+
+// UniqueIssuer issues random strings that never repeat within its lifetime,
+// every issued value is remembered, so memory grows with the number of values issued.
+// It is safe for concurrent use.
+type UniqueIssuer struct {
+	mu     sync.Mutex
+	kind   int
+	size   int
+	issued map[string]struct{}
+}
+
+// NewUniqueIssuer creates an issuer of random strings of the given kind and size, same as String(kind, size).
+func NewUniqueIssuer(kind, size int) *UniqueIssuer {
+	return &UniqueIssuer{
+		kind:   kind,
+		size:   size,
+		issued: make(map[string]struct{}),
+	}
+}
+
+// Issue returns a random string that has not been issued before,
+// ErrIssuerExhausted is returned if every retry collides with an issued value.
+func (u *UniqueIssuer) Issue() (string, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for i := 0; i < maxIssueRetries; i++ {
+		s := String(u.kind, u.size)
+		if _, ok := u.issued[s]; !ok {
+			u.issued[s] = struct{}{}
+			return s, nil
+		}
+	}
+
+	return "", ErrIssuerExhausted
+}
+
+// Len returns the number of values issued so far.
+func (u *UniqueIssuer) Len() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return len(u.issued)
+}
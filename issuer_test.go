@@ -0,0 +1,42 @@
+package buuid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniqueIssuer(t *testing.T) {
+	// R_NUM with size 2 has a capacity of 100 values
+	u := NewUniqueIssuer(R_NUM, 2)
+
+	seen := map[string]bool{}
+	for i := 0; i < 95; i++ {
+		s, err := u.Issue()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(s))
+		assert.False(t, seen[s])
+		seen[s] = true
+	}
+	assert.Equal(t, 95, u.Len())
+}
+
+func TestUniqueIssuer_Exhausted(t *testing.T) {
+	u := NewUniqueIssuer(R_NUM, 1)
+
+	for i := 0; i < 10; i++ {
+		_, err := u.Issue()
+		assert.NoError(t, err)
+	}
+
+	_, err := u.Issue()
+	assert.ErrorIs(t, err, ErrIssuerExhausted)
+	assert.Equal(t, 10, u.Len())
+}
+
+func BenchmarkUniqueIssuer_Issue(b *testing.B) {
+	u := NewUniqueIssuer(R_All, 16)
+	for i := 0; i < b.N; i++ {
+		_, _ = u.Issue()
+	}
+}
@@ -2,9 +2,14 @@ package buuid
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/binary"
-	"math/big"
+	"errors"
+	"fmt"
+	"io"
+	"math"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -25,41 +30,52 @@ var (
 	allChars    = []byte("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
 	charSets    = [][]byte{nil, numChars, upperChars, nil, lowerChars, nil, nil, allChars}
 	defaultRand = &lockedRandSource{}
+
+	// defaultGenerator backs the package level functions with crypto/rand
+	defaultGenerator = NewGenerator(rand.Reader)
 )
 
 type lockedRandSource struct {
 	mu sync.Mutex
+	r  io.Reader // crypto/rand if nil
 }
 
 func (r *lockedRandSource) Int63() int64 {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	src := r.r
+	if src == nil {
+		src = rand.Reader
+	}
 	var b [8]byte
-	_, err := rand.Read(b[:])
+	_, err := io.ReadFull(src, b[:])
 	if err != nil {
 		return time.Now().UnixNano()
 	}
 	return int64(binary.BigEndian.Uint64(b[:]) & (1<<63 - 1))
 }
 
-// String generates random strings of any length of multiple types, default length is 6 if size is empty
-// example: String(R_ALL), String(R_ALL, 16), String(R_NUM|R_LOWER, 16)
-func String(kind int, size ...int) string {
-	return string(Bytes(kind, size...))
+// readRandom fills b with random bytes from the default generator.
+func readRandom(b []byte) {
+	defaultGenerator.read(b)
 }
 
-// Bytes generates random strings of any length of multiple types, default length is 6 if bytesLen is empty
-// example: Bytes(R_ALL), Bytes(R_ALL, 16), Bytes(R_NUM|R_LOWER, 16)
-func Bytes(kind int, bytesLen ...int) []byte {
+// randIntn returns a random number in [0, n) from the default generator, n must be greater than 0.
+func randIntn(n int) int {
+	return defaultGenerator.intn(n)
+}
+
+// randFloat returns a random floating point number in [0, 1) with 53 bits of precision from the default generator.
+func randFloat() float64 {
+	return defaultGenerator.float()
+}
+
+// kindChars returns the character set for kind, kind outside [1, 7] is treated as R_All.
+func kindChars(kind int) []byte {
 	if kind > 7 || kind < 1 {
 		kind = R_All
 	}
 
-	length := 6 // default length 6
-	if len(bytesLen) > 0 && bytesLen[0] > 0 {
-		length = bytesLen[0]
-	}
-
 	chars := charSets[kind]
 	if chars == nil {
 		// Handle combined character sets
@@ -76,81 +92,206 @@ func Bytes(kind int, bytesLen ...int) []byte {
 		chars = combined
 	}
 
-	result := make([]byte, length)
-	for i := range result {
-		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
-		if err != nil {
-			n = big.NewInt(defaultRand.Int63() % int64(len(chars)))
-		}
-		result[i] = chars[n.Int64()]
-	}
+	return chars
+}
 
-	return result
+// String generates random strings of any length of multiple types, default length is 6 if size is empty
+// example: String(R_ALL), String(R_ALL, 16), String(R_NUM|R_LOWER, 16)
+func String(kind int, size ...int) string {
+	return string(Bytes(kind, size...))
+}
+
+// Bytes generates random strings of any length of multiple types, default length is 6 if bytesLen is empty
+// example: Bytes(R_ALL), Bytes(R_ALL, 16), Bytes(R_NUM|R_LOWER, 16)
+func Bytes(kind int, bytesLen ...int) []byte {
+	return defaultGenerator.Bytes(kind, bytesLen...)
+}
+
+// CharsetSize returns the number of distinct characters String and Bytes draw from for kind,
+// kind outside [1, 7] is treated as R_All.
+// example: CharsetSize(R_NUM|R_LOWER) returns 36
+func CharsetSize(kind int) int {
+	return len(kindChars(kind))
 }
 
 // Int generates random numbers of specified range size,
-// compatible with Int(), Int(max), Int(min, max), Int(max, min) 4 ways, min<=random number<=max
+// compatible with Int(), Int(max), Int(min, max), Int(max, min) 4 ways, min<=random number<=max,
+// a negative single max gives [max, 0].
+// Note that max is inclusive, unlike math/rand.Intn, use IntRange for an exclusive max.
 func Int(rangeSize ...int) int {
-	var min, max int
-
-	switch len(rangeSize) {
-	case 0:
-		min, max = 0, 100 // default 0~100
-	case 1:
-		min, max = 0, rangeSize[0]
-	default:
-		if rangeSize[0] > rangeSize[1] {
-			min, max = rangeSize[1], rangeSize[0]
-		} else {
-			min, max = rangeSize[0], rangeSize[1]
+	return defaultGenerator.Int(rangeSize...)
+}
+
+// IntRange generates a random number in [min, max] if inclusive is true, otherwise in [min, max),
+// min and max are swapped if min > max. An error is returned for an empty exclusive range (min == max),
+// where math/rand.Intn(0) panics.
+// example: IntRange(0, 10, false) behaves like math/rand.Intn(10)
+func IntRange(min, max int, inclusive bool) (int, error) {
+	if min > max {
+		min, max = max, min
+	}
+	if !inclusive {
+		if min == max {
+			return 0, fmt.Errorf("buuid: exclusive range [%d, %d) is empty", min, max)
 		}
+		max--
 	}
+	return Int(min, max), nil
+}
 
-	n, err := rand.Int(rand.Reader, big.NewInt(int64(max-min+1)))
-	if err != nil {
-		return min + int(defaultRand.Int63()%int64(max-min+1))
+// edgeIntProb is the probability that EdgeInt returns one of EdgeInts.
+const edgeIntProb = 0.25
+
+// EdgeInts holds the boundary values EdgeInt favors: zero and its neighbors, the ends of the int range
+// and their neighbors, and the ends of the 8, 16 and 32-bit integer ranges.
+var EdgeInts = []int{
+	0, 1, -1, 2, -2,
+	math.MaxInt, math.MaxInt - 1, math.MinInt, math.MinInt + 1,
+	math.MaxInt8, math.MinInt8, math.MaxUint8,
+	math.MaxInt16, math.MinInt16, math.MaxUint16,
+	math.MaxInt32, math.MinInt32,
+}
+
+// EdgeInt returns, with probability edgeIntProb (1/4), a value of EdgeInts picked uniformly, and otherwise
+// a number drawn uniformly from the whole int range, biasing property-based tests and fuzzing toward the
+// boundary values that commonly expose overflow and off-by-one bugs.
+func EdgeInt() int {
+	if randFloat() < edgeIntProb {
+		return EdgeInts[randIntn(len(EdgeInts))]
 	}
-	return min + int(n.Int64())
+
+	var b [8]byte
+	readRandom(b[:])
+	return int(binary.BigEndian.Uint64(b[:]))
 }
 
-// Float64 generates a random floating point number of the specified range size,
-// Four types of passing references are supported, example: Float64(dpLength), Float64(dpLength, max),
-// Float64(dpLength, min, max), Float64(dpLength, max, min), min<=random numbers<=max
-func Float64(dpLength int, rangeSize ...int) float64 {
-	var min, max int
-
-	switch len(rangeSize) {
-	case 0:
-		min, max = 0, 100 // default 0~100
-	case 1:
-		min, max = 0, rangeSize[0]
-	default:
-		if rangeSize[0] > rangeSize[1] {
-			min, max = rangeSize[1], rangeSize[0]
-		} else {
-			min, max = rangeSize[0], rangeSize[1]
+// KeyedInt returns a number in [min, max] derived from a SHA-256 hash of key, so the same key always maps
+// to the same number while different keys spread uniformly over the range, for stable bucketing such as
+// feature flag rollouts. It is deterministic, not random. min and max are swapped if min > max.
+// example: KeyedInt("user-42", 0, 99) < 10 puts about 10% of users in a rollout
+func KeyedInt(key string, min, max int) int {
+	if min > max {
+		min, max = max, min
+	}
+
+	span, limit := rejectLimit(min, max)
+
+	// Rehash until the value is below the largest multiple of span, to stay unbiased.
+	sum := sha256.Sum256([]byte(key))
+	for {
+		v := binary.BigEndian.Uint64(sum[:])
+		if span == 0 {
+			return min + int(v)
 		}
+		if v < limit {
+			return min + int(v%span)
+		}
+		sum = sha256.Sum256(sum[:])
+	}
+}
+
+// intsBufferSize is the most random bytes IntsInRange reads from the default generator at once.
+const intsBufferSize = 8 * 512
+
+// IntsInRange generates count random numbers in [min, max], min and max are swapped if min > max.
+// Random bytes are read in large batches instead of once per number, and values above the largest
+// multiple of the range are rejected, so the numbers are unbiased like those of Int.
+// An empty slice is returned if count is not positive.
+// example: IntsInRange(1000, 1, 6)
+func IntsInRange(count, min, max int) []int {
+	if count <= 0 {
+		return []int{}
 	}
+	if min > max {
+		min, max = max, min
+	}
+
+	span, limit := rejectLimit(min, max)
 
-	// Generate decimal part
-	dp := 0.0
-	if dpLength > 0 {
-		dpmax := big.NewInt(10)
-		dpmax.Exp(dpmax, big.NewInt(int64(dpLength)), nil)
-		n, err := rand.Int(rand.Reader, dpmax)
-		if err != nil {
-			n = big.NewInt(defaultRand.Int63() % dpmax.Int64())
+	result := make([]int, 0, count)
+	size := intsBufferSize
+	if count < size/8 {
+		size = 8 * count
+	}
+	buf := make([]byte, size)
+	pos := len(buf)
+	for len(result) < count {
+		if pos == len(buf) {
+			readRandom(buf)
+			pos = 0
+		}
+		v := binary.BigEndian.Uint64(buf[pos:])
+		pos += 8
+		if span == 0 {
+			result = append(result, min+int(v))
+		} else if v < limit {
+			result = append(result, min+int(v%span))
 		}
-		dp = float64(n.Int64()) / float64(dpmax.Int64())
 	}
 
-	// Generate integer part
-	intPart, err := rand.Int(rand.Reader, big.NewInt(int64(max-min)))
-	if err != nil {
-		intPart = big.NewInt(defaultRand.Int63() % int64(max-min))
+	return result
+}
+
+// maxIntDigits is the number of decimal digits of math.MaxInt.
+var maxIntDigits = len(strconv.Itoa(math.MaxInt))
+
+// IntWithDigits generates a random number with exactly n decimal digits and no leading zero,
+// min<=random number<=max where min=10^(n-1) and max=10^n-1, n=1 returns a number in [0, 9].
+// n must be in [1, 19] (10 on 32-bit platforms), for the widest n the max is math.MaxInt.
+func IntWithDigits(n int) (int, error) {
+	if n < 1 || n > maxIntDigits {
+		return 0, fmt.Errorf("buuid: n must be in [1, %d]", maxIntDigits)
+	}
+	if n == 1 {
+		return Int(0, 9), nil
+	}
+
+	min := 1
+	for i := 1; i < n; i++ {
+		min *= 10
+	}
+	max := math.MaxInt
+	if n < maxIntDigits {
+		max = min*10 - 1
 	}
 
-	return float64(min) + float64(intPart.Int64()) + dp
+	return Int(min, max), nil
+}
+
+// GroupedNumber generates a random number of n decimal digits with no leading zero, formatted into
+// groups of groupSize digits joined by sep, counted from the left so only the last group may be shorter.
+// n=1 yields a digit in [0, 9], n and groupSize must be positive.
+// example: GroupedNumber(12, 4, " ") returns 1234 5678 9012
+func GroupedNumber(n, groupSize int, sep string) (string, error) {
+	if n <= 0 {
+		return "", errors.New("buuid: n must be positive")
+	}
+	if groupSize <= 0 {
+		return "", errors.New("buuid: groupSize must be positive")
+	}
+
+	digits := Bytes(R_NUM, n)
+	if n > 1 && digits[0] == '0' {
+		digits[0] = '1' + byte(randIntn(9))
+	}
+
+	var b strings.Builder
+	b.Grow(n + (n-1)/groupSize*len(sep))
+	for i := 0; i < n; i += groupSize {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.Write(digits[i:min(i+groupSize, n)])
+	}
+
+	return b.String(), nil
+}
+
+// Float64 generates a random floating point number of the specified range size,
+// Four types of passing references are supported, example: Float64(dpLength), Float64(dpLength, max),
+// Float64(dpLength, min, max), Float64(dpLength, max, min), min<=random numbers<=max
+func Float64(dpLength int, rangeSize ...int) float64 {
+	return defaultGenerator.Float64(dpLength, rangeSize...)
 }
 
 // NewID generates a milliseconds+random number ID.
@@ -166,17 +307,136 @@ func NewID() int64 {
 	return now + int64(binary.LittleEndian.Uint64(buf[:])%1000000)
 }
 
+// OrderedIDs generates n IDs in the format of NewID that are strictly increasing, hence unique.
+// Each millisecond holds at most seriesBucketSize IDs with distinct sorted random parts,
+// beyond that the millisecond part is advanced without waiting for the clock.
+func OrderedIDs(n int) []int64 {
+	if n <= 0 {
+		return []int64{}
+	}
+
+	ids := make([]int64, 0, n)
+	ms := time.Now().UnixMilli()
+	for {
+		for _, r := range sortedDistinctInts(1000000, min(n-len(ids), seriesBucketSize)) {
+			ids = append(ids, ms*1000000+int64(r))
+		}
+		if len(ids) == n {
+			return ids
+		}
+
+		next := time.Now().UnixMilli()
+		if next <= ms {
+			next = ms + 1
+		}
+		ms = next
+	}
+}
+
+// IDOverflowTime returns the first millisecond at which NewID can overflow int64,
+// that is 2262-04-11 23:47:16.854 UTC, IDs generated before it are always positive.
+func IDOverflowTime() time.Time {
+	const maxRandom = 999999
+	return time.UnixMilli((math.MaxInt64-maxRandom)/1000000 + 1).UTC()
+}
+
 // NewStringID generates a string ID, the hexadecimal form of NewID(), total 16 bytes.
 func NewStringID() string {
 	return strconv.FormatInt(NewID(), 16)
 }
 
+// NewStringIDUpper generates a string ID, the uppercase hexadecimal form of NewID(), total 16 bytes.
+func NewStringIDUpper() string {
+	return strings.ToUpper(strconv.FormatInt(NewID(), 16))
+}
+
+// NewPaddedID generates a string ID, the decimal form of NewID() zero-padded to 19 bytes,
+// the width of math.MaxInt64, so sorting the strings sorts the IDs numerically, hence by time.
+// example: 1651234567890123456, 0000000000000000042 for a small value
+func NewPaddedID() string {
+	var buf [19]byte
+	for i := range buf {
+		buf[i] = '0'
+	}
+	s := strconv.FormatInt(NewID(), 10)
+	copy(buf[len(buf)-len(s):], s)
+	return string(buf[:])
+}
+
+// NewIDPair generates an ID and its hexadecimal string form from the same value,
+// the string equals strconv.FormatInt(id, 16).
+func NewIDPair() (int64, string) {
+	id := NewID()
+	return id, strconv.FormatInt(id, 16)
+}
+
 // NewSeriesID generates a datetime+random string ID,
 // datetime is microsecond precision, 20 bytes, random is 6 bytes, total 26 bytes.
 // example: 20060102150405000000123456
 func NewSeriesID() string {
+	return formatSeriesID(time.Now(), Int(0, 999999))
+}
+
+// NewSeriesIDSalted generates a series ID in the format of NewSeriesID whose random part is derived
+// from a SHA-256 hash of salt and fresh random bytes. The salt should be unique per node, such as
+// a hostname or pod name, so nodes whose random sources are correlated, for instance when the
+// time-based fallback of crypto/rand kicks in on several nodes at once, still draw different suffixes.
+// example: NewSeriesIDSalted("node-1")
+func NewSeriesIDSalted(salt string) string {
+	var random [8]byte
+	readRandom(random[:])
+	return formatSeriesID(time.Now(), saltedSuffix(salt, random[:]))
+}
+
+// saltedSuffix mixes salt and random into a number in [0, 999999].
+func saltedSuffix(salt string, random []byte) int {
+	h := sha256.New()
+	h.Write(random)
+	h.Write([]byte(salt))
+	sum := h.Sum(nil)
+	return int(binary.BigEndian.Uint64(sum) % 1000000)
+}
+
+// seriesBucketSize is the number of suffixes NewSeriesIDs draws per microsecond before
+// advancing the clock, half of the 10^6 capacity so distinct suffixes stay cheap to sample.
+const seriesBucketSize = 500000
+
+// NewSeriesIDs generates n series IDs in the format of NewSeriesID that are unique within the batch.
+// Suffixes are distinct within each microsecond, at most seriesBucketSize IDs share a microsecond,
+// beyond that the datetime part is advanced by one microsecond, IDs are returned in datetime order.
+func NewSeriesIDs(n int) []string {
+	if n <= 0 {
+		return []string{}
+	}
+
+	ids := make([]string, 0, n)
+	t := time.Now().Truncate(time.Microsecond)
+	used := make(map[int]struct{}, min(n, seriesBucketSize))
+
+	for len(ids) < n {
+		if len(used) == seriesBucketSize {
+			next := time.Now().Truncate(time.Microsecond)
+			if !next.After(t) {
+				next = t.Add(time.Microsecond)
+			}
+			t = next
+			clear(used)
+		}
+
+		random := Int(0, 999999)
+		if _, ok := used[random]; ok {
+			continue
+		}
+		used[random] = struct{}{}
+		ids = append(ids, formatSeriesID(t, random))
+	}
+
+	return ids
+}
+
+// formatSeriesID formats t and a 6-digit random number as a series ID.
+func formatSeriesID(t time.Time, random int) string {
 	var buf [26]byte
-	t := time.Now()
 
 	// Format datetime with microsecond precision (14 bytes)
 	copy(buf[:14], t.Format("20060102150405"))
@@ -190,8 +450,7 @@ func NewSeriesID() string {
 	buf[18] = '0' + byte(micro/10%10)
 	buf[19] = '0' + byte(micro%10)
 
-	// Generate a 6-digit random number
-	random := Int(0, 999999)
+	// Add the 6-digit random number
 	for i := 20; i < 26; i++ {
 		buf[i] = '0' + byte(random%10)
 		random /= 10
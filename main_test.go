@@ -1,6 +1,11 @@
 package buuid
 
 import (
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -32,6 +37,199 @@ func TestInt(t *testing.T) {
 		n := Int(20, 10)
 		assert.True(t, n >= 10 && n <= 20)
 	}
+
+	seenMin, seenMax := false, false
+	for i := 0; i < l; i++ {
+		// randomly generated numbers with a negative max: [max, 0]
+		n := Int(-5)
+		assert.True(t, n >= -5 && n <= 0, n)
+		seenMin = seenMin || n == -5
+		seenMax = seenMax || n == 0
+	}
+	assert.True(t, seenMin && seenMax)
+
+	// ranges wider than math.MaxInt, up to the full int range
+	neg := 0
+	for i := 0; i < 1000; i++ {
+		if Int(math.MinInt, math.MaxInt) < 0 {
+			neg++
+		}
+		n := Int(-1, math.MaxInt)
+		assert.True(t, n >= -1)
+		n = Int(math.MinInt, 1)
+		assert.True(t, n <= 1)
+	}
+	assert.InDelta(t, 500, neg, 100)
+	assert.Equal(t, math.MaxInt, Int(math.MaxInt, math.MaxInt))
+}
+
+func TestIntRange(t *testing.T) {
+	seenMin, seenMax := false, false
+	for i := 0; i < 1000; i++ {
+		n, err := IntRange(0, 3, true)
+		assert.NoError(t, err)
+		assert.True(t, n >= 0 && n <= 3)
+		seenMin = seenMin || n == 0
+		seenMax = seenMax || n == 3
+	}
+	assert.True(t, seenMin && seenMax)
+
+	seenMin, seenMax = false, false
+	for i := 0; i < 1000; i++ {
+		n, err := IntRange(0, 3, false)
+		assert.NoError(t, err)
+		assert.True(t, n >= 0 && n < 3)
+		seenMin = seenMin || n == 0
+		seenMax = seenMax || n == 2
+	}
+	assert.True(t, seenMin && seenMax)
+
+	for i := 0; i < 100; i++ {
+		n, err := IntRange(10, -10, false)
+		assert.NoError(t, err)
+		assert.True(t, n >= -10 && n < 10)
+	}
+
+	n, err := IntRange(5, 5, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+	n, err = IntRange(5, 6, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	// an empty exclusive range has no value
+	_, err = IntRange(5, 5, false)
+	assert.Error(t, err)
+
+	// the full int range and the widest exclusive range
+	for i := 0; i < 100; i++ {
+		_, err = IntRange(math.MinInt, math.MaxInt, true)
+		assert.NoError(t, err)
+		n, err = IntRange(math.MinInt, math.MaxInt, false)
+		assert.NoError(t, err)
+		assert.True(t, n < math.MaxInt)
+	}
+}
+
+func TestIntsInRange(t *testing.T) {
+	assert.Equal(t, 0, len(IntsInRange(0, 1, 6)))
+
+	l := 60000
+	counts := map[int]int{}
+	for _, v := range IntsInRange(l, 6, 1) {
+		assert.True(t, v >= 1 && v <= 6)
+		counts[v]++
+	}
+	assert.Equal(t, 6, len(counts))
+	for v := 1; v <= 6; v++ {
+		assert.InDelta(t, l/6, counts[v], float64(l/6)*0.05)
+	}
+
+	// counts beyond one buffer, a single value range and the full int range
+	assert.Equal(t, 5000, len(IntsInRange(5000, 0, 1000)))
+	for _, v := range IntsInRange(10, 7, 7) {
+		assert.Equal(t, 7, v)
+	}
+	neg := 0
+	for _, v := range IntsInRange(1000, math.MinInt, math.MaxInt) {
+		if v < 0 {
+			neg++
+		}
+	}
+	assert.InDelta(t, 500, neg, 100)
+}
+
+func TestEdgeInt(t *testing.T) {
+	l := 40000
+	edges := map[int]bool{}
+	for _, v := range EdgeInts {
+		edges[v] = true
+	}
+
+	hits := map[int]int{}
+	total := 0
+	for i := 0; i < l; i++ {
+		v := EdgeInt()
+		if edges[v] {
+			hits[v]++
+			total++
+		}
+	}
+
+	// a uniform int would almost never hit an edge value
+	assert.InDelta(t, edgeIntProb, float64(total)/float64(l), 0.02)
+	assert.Equal(t, len(EdgeInts), len(hits))
+	assert.Greater(t, hits[math.MaxInt], 0)
+	assert.Greater(t, hits[math.MinInt], 0)
+}
+
+func TestKeyedInt(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		key := "user-" + strconv.Itoa(i)
+		v := KeyedInt(key, 1, 6)
+		assert.True(t, v >= 1 && v <= 6)
+		assert.Equal(t, v, KeyedInt(key, 1, 6))
+		assert.Equal(t, v, KeyedInt(key, 6, 1))
+	}
+
+	l := 60000
+	counts := map[int]int{}
+	for i := 0; i < l; i++ {
+		counts[KeyedInt("key-"+strconv.Itoa(i), 0, 5)]++
+	}
+	assert.Equal(t, 6, len(counts))
+	for v := 0; v < 6; v++ {
+		assert.InDelta(t, l/6, counts[v], float64(l/6)*0.05)
+	}
+
+	assert.Equal(t, 7, KeyedInt("x", 7, 7))
+	assert.Equal(t, KeyedInt("x", math.MinInt, math.MaxInt), KeyedInt("x", math.MinInt, math.MaxInt))
+}
+
+func TestIntWithDigits(t *testing.T) {
+	for n := 1; n <= maxIntDigits; n++ {
+		for i := 0; i < 100; i++ {
+			v, err := IntWithDigits(n)
+			assert.NoError(t, err)
+			assert.Equal(t, n, len(strconv.Itoa(v)))
+		}
+	}
+
+	_, err := IntWithDigits(0)
+	assert.Error(t, err)
+	_, err = IntWithDigits(maxIntDigits + 1)
+	assert.Error(t, err)
+}
+
+func TestGroupedNumber(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		s, err := GroupedNumber(12, 4, " ")
+		assert.NoError(t, err)
+		groups := strings.Split(s, " ")
+		assert.Equal(t, 3, len(groups))
+		for _, g := range groups {
+			assert.Equal(t, 4, len(g))
+		}
+		assert.NotEqual(t, byte('0'), s[0])
+
+		s, err = GroupedNumber(10, 3, "-")
+		assert.NoError(t, err)
+		groups = strings.Split(s, "-")
+		assert.Equal(t, []int{3, 3, 3, 1}, []int{len(groups[0]), len(groups[1]), len(groups[2]), len(groups[3])})
+		digits := strings.ReplaceAll(s, "-", "")
+		v, err := strconv.ParseInt(digits, 10, 64)
+		assert.NoError(t, err)
+		assert.Equal(t, 10, len(strconv.FormatInt(v, 10)))
+	}
+
+	s, err := GroupedNumber(3, 5, " ")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(s))
+
+	_, err = GroupedNumber(0, 4, " ")
+	assert.Error(t, err)
+	_, err = GroupedNumber(4, 0, " ")
+	assert.Error(t, err)
 }
 
 func TestFloat64(t *testing.T) {
@@ -82,24 +280,156 @@ func TestString(t *testing.T) {
 	assert.Equal(t, 32, len(Bytes(R_All, 32)))
 }
 
+func TestCharsetSize(t *testing.T) {
+	sizes := map[int]int{
+		R_NUM:                 10,
+		R_UPPER:               26,
+		R_NUM | R_UPPER:       36,
+		R_LOWER:               26,
+		R_NUM | R_LOWER:       36,
+		R_UPPER | R_LOWER:     52,
+		R_All:                 62,
+		0:                     62,
+		-1:                    62,
+		8:                     62,
+		R_NUM | R_UPPER | 100: 62,
+	}
+	for kind, size := range sizes {
+		assert.Equal(t, size, CharsetSize(kind), kind)
+	}
+}
+
 func TestNewID(t *testing.T) {
 	for i := 0; i < 10; i++ {
 		assert.GreaterOrEqual(t, NewID(), time.Now().UnixMilli()*1000000)
 	}
 }
 
+func TestOrderedIDs(t *testing.T) {
+	assert.Equal(t, 0, len(OrderedIDs(0)))
+
+	before := time.Now().UnixMilli()
+	for _, n := range []int{1, 1000, seriesBucketSize*2 + 1} {
+		ids := OrderedIDs(n)
+		assert.Equal(t, n, len(ids))
+		assert.True(t, ids[0]/1000000 >= before)
+		for i := 1; i < len(ids); i++ {
+			if ids[i] <= ids[i-1] {
+				t.Fatalf("ids[%d]=%d is not greater than ids[%d]=%d", i, ids[i], i-1, ids[i-1])
+			}
+		}
+	}
+}
+
+func TestIDOverflowTime(t *testing.T) {
+	tm := IDOverflowTime()
+	assert.Equal(t, time.Date(2262, 4, 11, 23, 47, 16, 854000000, time.UTC), tm)
+
+	// the millisecond before overflow still fits the largest random part, the overflow one does not
+	maxID := new(big.Int).SetInt64(math.MaxInt64)
+	id := func(ms int64) *big.Int {
+		n := new(big.Int).Mul(big.NewInt(ms), big.NewInt(1000000))
+		return n.Add(n, big.NewInt(999999))
+	}
+	assert.True(t, id(tm.UnixMilli()-1).Cmp(maxID) <= 0)
+	assert.True(t, id(tm.UnixMilli()).Cmp(maxID) > 0)
+}
+
 func TestNewStringID(t *testing.T) {
 	for i := 0; i < 10; i++ {
 		assert.Equal(t, 16, len(NewStringID()))
 	}
 }
 
+func TestNewStringIDUpper(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		s := NewStringIDUpper()
+		assert.Equal(t, 16, len(s))
+		assert.Equal(t, strings.ToUpper(s), s)
+
+		upper, err := strconv.ParseInt(s, 16, 64)
+		assert.NoError(t, err)
+		lower, err := strconv.ParseInt(strings.ToLower(s), 16, 64)
+		assert.NoError(t, err)
+		assert.Equal(t, upper, lower)
+	}
+}
+
+func TestNewPaddedID(t *testing.T) {
+	ids := make([]string, 1000)
+	for i := range ids {
+		ids[i] = NewPaddedID()
+		assert.Equal(t, 19, len(ids[i]))
+	}
+
+	nums := make([]int64, len(ids))
+	for i, s := range ids {
+		n, err := strconv.ParseInt(s, 10, 64)
+		assert.NoError(t, err)
+		nums[i] = n
+	}
+
+	sort.Strings(ids)
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+	for i := range ids {
+		n, _ := strconv.ParseInt(ids[i], 10, 64)
+		assert.Equal(t, nums[i], n)
+	}
+}
+
+func TestNewIDPair(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		id, s := NewIDPair()
+		n, err := strconv.ParseInt(s, 16, 64)
+		assert.NoError(t, err)
+		assert.Equal(t, id, n)
+	}
+}
+
 func TestNewNewSeriesID(t *testing.T) {
 	for i := 0; i < 10; i++ {
 		assert.Equal(t, 26, len(NewSeriesID()))
 	}
 }
 
+func TestNewSeriesIDSalted(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		s := NewSeriesIDSalted("node-1")
+		assert.Equal(t, 26, len(s))
+		assert.True(t, isDigits(s))
+	}
+
+	// with the same clock and the same random bytes, as with correlated random sources,
+	// different salts still give different suffixes
+	now := time.Now()
+	random := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	ids := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		ids[formatSeriesID(now, saltedSuffix("node-"+strconv.Itoa(i), random))] = true
+	}
+	assert.True(t, len(ids) >= 99)
+
+	suffix := saltedSuffix("node-1", random)
+	assert.Equal(t, suffix, saltedSuffix("node-1", random))
+	assert.True(t, suffix >= 0 && suffix <= 999999)
+}
+
+func TestNewSeriesIDs(t *testing.T) {
+	assert.Equal(t, 0, len(NewSeriesIDs(0)))
+
+	for _, n := range []int{1, 1000, seriesBucketSize + 1000} {
+		ids := NewSeriesIDs(n)
+		assert.Equal(t, n, len(ids))
+
+		seen := make(map[string]struct{}, n)
+		for _, id := range ids {
+			assert.Equal(t, 26, len(id))
+			seen[id] = struct{}{}
+		}
+		assert.Equal(t, n, len(seen))
+	}
+}
+
 func BenchmarkInt(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		Int()
@@ -112,6 +442,33 @@ func BenchmarkInt_10000(b *testing.B) {
 	}
 }
 
+func BenchmarkIntsInRange_1000(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		IntsInRange(1000, 0, 10000)
+	}
+}
+
+func BenchmarkInt_Loop_1000(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ints := make([]int, 1000)
+		for j := range ints {
+			ints[j] = Int(0, 10000)
+		}
+	}
+}
+
+func BenchmarkEdgeInt(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		EdgeInt()
+	}
+}
+
+func BenchmarkKeyedInt(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		KeyedInt("user-42", 0, 99)
+	}
+}
+
 func BenchmarkFloat64_0(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		Float64(0)
@@ -124,6 +481,12 @@ func BenchmarkFloat64(b *testing.B) {
 	}
 }
 
+func BenchmarkGroupedNumber(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = GroupedNumber(12, 4, " ")
+	}
+}
+
 func BenchmarkString_ALL_6(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		String(R_All)
@@ -160,8 +523,44 @@ func BenchmarkNewStringID(b *testing.B) {
 	}
 }
 
+func BenchmarkNewStringIDUpper(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewStringIDUpper()
+	}
+}
+
+func BenchmarkNewPaddedID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewPaddedID()
+	}
+}
+
+func BenchmarkOrderedIDs_1000(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		OrderedIDs(1000)
+	}
+}
+
+func BenchmarkNewIDPair(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewIDPair()
+	}
+}
+
 func BenchmarkNewSeriesID(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		NewSeriesID()
 	}
 }
+
+func BenchmarkNewSeriesIDSalted(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewSeriesIDSalted("node-1")
+	}
+}
+
+func BenchmarkNewSeriesIDs_1000(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewSeriesIDs(1000)
+	}
+}
@@ -0,0 +1,94 @@
+package buuid
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+const (
+	maxLabelLen    = 63
+	maxHostnameLen = 253
+)
+
+var hostnameChars = []byte("abcdefghijklmnopqrstuvwxyz0123456789-")
+
+// Hostname generates a random DNS-valid hostname of labels dot-separated labels,
+// each label is 1 to 63 lowercase letters, digits or hyphens, starts with a letter and does not end with a hyphen,
+// and the total length does not exceed 253. labels must be in [1, 127].
+// example: Hostname(3) returns something like "k3x.q-9ab.zt"
+func Hostname(labels int) (string, error) {
+	if labels < 1 || 2*labels-1 > maxHostnameLen {
+		return "", errors.New("buuid: labels must be in [1, 127]")
+	}
+
+	var b strings.Builder
+	b.Grow(maxHostnameLen)
+
+	// budget is the number of characters left for the current and remaining labels,
+	// every remaining label after the current one needs at least 1 character and a dot
+	budget := maxHostnameLen - (labels - 1)
+	for i := 0; i < labels; i++ {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+
+		rest := labels - i - 1
+		n := Int(1, min(maxLabelLen, budget-rest))
+		budget -= n
+
+		b.WriteByte(lowerChars[randIntn(len(lowerChars))])
+		for j := 1; j < n; j++ {
+			chars := hostnameChars
+			if j == n-1 {
+				chars = hostnameChars[:len(hostnameChars)-1] // no trailing hyphen
+			}
+			b.WriteByte(chars[randIntn(len(chars))])
+		}
+	}
+
+	return b.String(), nil
+}
+
+// RandomCIDR generates a random IPv4 CIDR block with a prefix length in [minPrefix, maxPrefix]
+// whose network address has every host bit zeroed, minPrefix and maxPrefix are swapped if
+// minPrefix > maxPrefix and must be in [0, 32].
+// example: RandomCIDR(16, 24) returns something like "10.84.192.0/18"
+func RandomCIDR(minPrefix, maxPrefix int) (string, error) {
+	if minPrefix > maxPrefix {
+		minPrefix, maxPrefix = maxPrefix, minPrefix
+	}
+	if minPrefix < 0 || maxPrefix > 32 {
+		return "", errors.New("buuid: prefix lengths must be in [0, 32]")
+	}
+
+	var b [4]byte
+	readRandom(b[:])
+	prefix, err := netip.AddrFrom4(b).Prefix(Int(minPrefix, maxPrefix))
+	if err != nil {
+		return "", err
+	}
+
+	return prefix.String(), nil
+}
+
+// MAC generates a random unicast, locally administered 48-bit MAC address,
+// such addresses cannot clash with the vendor-assigned address of a real device.
+// example: MAC().String() returns something like "4e:1b:9c:02:7d:e5"
+func MAC() net.HardwareAddr {
+	mac := make(net.HardwareAddr, 6)
+	readRandom(mac)
+	mac[0] = mac[0]&^0x01 | 0x02 // unicast, locally administered
+	return mac
+}
+
+// MACWithOUI generates a 48-bit MAC address whose first three bytes are the vendor OUI oui
+// and whose last three bytes are random, simulating a device made by that vendor.
+// example: MACWithOUI([3]byte{0x00, 0x1b, 0x63}) returns something like 00:1b:63:a4:0e:7f
+func MACWithOUI(oui [3]byte) net.HardwareAddr {
+	mac := make(net.HardwareAddr, 6)
+	copy(mac, oui[:])
+	readRandom(mac[3:])
+	return mac
+}
@@ -0,0 +1,111 @@
+package buuid
+
+import (
+	"net"
+	"net/netip"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostname(t *testing.T) {
+	label := regexp.MustCompile(`^[a-z]([a-z0-9-]*[a-z0-9])?$`)
+
+	for _, labels := range []int{1, 2, 3, 5, 10, 127} {
+		for i := 0; i < 50; i++ {
+			s, err := Hostname(labels)
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, len(s), 253)
+
+			parts := strings.Split(s, ".")
+			assert.Equal(t, labels, len(parts))
+			for _, p := range parts {
+				assert.True(t, len(p) >= 1 && len(p) <= 63)
+				assert.Regexp(t, label, p)
+			}
+		}
+	}
+
+	_, err := Hostname(0)
+	assert.Error(t, err)
+	_, err = Hostname(128)
+	assert.Error(t, err)
+}
+
+func TestRandomCIDR(t *testing.T) {
+	prefixes := map[int]bool{}
+	for i := 0; i < 1000; i++ {
+		s, err := RandomCIDR(24, 8)
+		assert.NoError(t, err)
+
+		p, err := netip.ParsePrefix(s)
+		assert.NoError(t, err)
+		assert.True(t, p.Addr().Is4())
+		assert.True(t, p.Bits() >= 8 && p.Bits() <= 24)
+		assert.Equal(t, p.Masked(), p, "host bits set in %s", s)
+		prefixes[p.Bits()] = true
+	}
+	assert.Equal(t, 17, len(prefixes))
+
+	s, err := RandomCIDR(32, 32)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(s, "/32"))
+	s, err = RandomCIDR(0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.0.0/0", s)
+
+	_, err = RandomCIDR(-1, 8)
+	assert.Error(t, err)
+	_, err = RandomCIDR(8, 33)
+	assert.Error(t, err)
+}
+
+func TestMAC(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		mac := MAC()
+		assert.Equal(t, 6, len(mac))
+		assert.Equal(t, byte(0), mac[0]&0x01)
+		assert.Equal(t, byte(0x02), mac[0]&0x02)
+
+		parsed, err := net.ParseMAC(mac.String())
+		assert.NoError(t, err)
+		assert.Equal(t, mac, parsed)
+		seen[mac.String()] = true
+	}
+	assert.Equal(t, 100, len(seen))
+}
+
+func TestMACWithOUI(t *testing.T) {
+	oui := [3]byte{0x00, 0x1b, 0x63}
+	suffixes := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		mac := MACWithOUI(oui)
+		assert.Equal(t, 6, len(mac))
+		assert.Equal(t, oui[:], []byte(mac[:3]))
+		assert.True(t, strings.HasPrefix(mac.String(), "00:1b:63:"))
+		suffixes[mac.String()[9:]] = true
+	}
+	assert.Greater(t, len(suffixes), 95)
+}
+
+func BenchmarkHostname(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = Hostname(3)
+	}
+}
+
+func BenchmarkRandomCIDR(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = RandomCIDR(8, 30)
+	}
+}
+
+func BenchmarkMACWithOUI(b *testing.B) {
+	oui := [3]byte{0x00, 0x1b, 0x63}
+	for i := 0; i < b.N; i++ {
+		MACWithOUI(oui)
+	}
+}
@@ -0,0 +1,75 @@
+package buuid
+
+import (
+	"bytes"
+	"errors"
+	"math/bits"
+)
+
+// obfuscateLen is the fixed width of ObfuscateID strings, the fewest base62 digits covering 2^64.
+const obfuscateLen = 11
+
+// feistelKeys are the fixed round keys of the ObfuscateID Feistel network.
+var feistelKeys = [4]uint32{0x9e3779b9, 0x7f4a7c15, 0xf39cc060, 0x5ced1c13}
+
+// ObfuscateID maps id to an 11-character base62 string through a fixed 4-round Feistel network over its
+// 64 bits, hiding the ordering of sequential IDs, DeobfuscateID reverses it.
+// The transform is a bijection with public keys, so it is obfuscation, not encryption.
+func ObfuscateID(id int64) string {
+	v := feistel(uint64(id), false)
+
+	var buf [obfuscateLen]byte
+	for i := obfuscateLen - 1; i >= 0; i-- {
+		buf[i] = allChars[v%62]
+		v /= 62
+	}
+	return string(buf[:])
+}
+
+// DeobfuscateID returns the ID an ObfuscateID string was made from.
+func DeobfuscateID(s string) (int64, error) {
+	if len(s) != obfuscateLen {
+		return 0, errors.New("buuid: invalid obfuscated ID length")
+	}
+
+	var v uint64
+	for i := 0; i < len(s); i++ {
+		d := bytes.IndexByte(allChars, s[i])
+		if d < 0 {
+			return 0, errors.New("buuid: invalid obfuscated ID character")
+		}
+		// 62^11 exceeds 2^64, reject values that overflow
+		hi, lo := bits.Mul64(v, 62)
+		if hi != 0 || lo+uint64(d) < lo {
+			return 0, errors.New("buuid: obfuscated ID out of range")
+		}
+		v = lo + uint64(d)
+	}
+
+	return int64(feistel(v, true)), nil
+}
+
+// feistel runs the Feistel network forward, or backward if inverse is true.
+func feistel(v uint64, inverse bool) uint64 {
+	l, r := uint32(v>>32), uint32(v)
+	for i := range feistelKeys {
+		if inverse {
+			k := feistelKeys[len(feistelKeys)-1-i]
+			l, r = r^feistelRound(l, k), l
+		} else {
+			l, r = r, l^feistelRound(r, feistelKeys[i])
+		}
+	}
+	return uint64(l)<<32 | uint64(r)
+}
+
+// feistelRound mixes x with the round key k.
+func feistelRound(x, k uint32) uint32 {
+	x ^= k
+	x ^= x >> 16
+	x *= 0x7feb352d
+	x ^= x >> 15
+	x *= 0x846ca68b
+	x ^= x >> 16
+	return x
+}
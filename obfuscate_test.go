@@ -0,0 +1,48 @@
+package buuid
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObfuscateID(t *testing.T) {
+	ids := []int64{0, 1, 2, -1, math.MaxInt64, math.MinInt64}
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, NewID())
+	}
+	for i := int64(1000); i < 11000; i++ {
+		ids = append(ids, i) // sequential IDs
+	}
+
+	seen := make(map[string]int64, len(ids))
+	for _, id := range ids {
+		s := ObfuscateID(id)
+		assert.Equal(t, 11, len(s))
+		assert.Equal(t, s, ObfuscateID(id))
+
+		if prev, ok := seen[s]; ok {
+			assert.Equal(t, prev, id, "collision")
+		}
+		seen[s] = id
+
+		back, err := DeobfuscateID(s)
+		assert.NoError(t, err)
+		assert.Equal(t, id, back)
+	}
+
+	// sequential IDs do not map to sequential strings
+	assert.NotEqual(t, ObfuscateID(1000)[:8], ObfuscateID(1001)[:8])
+
+	for _, s := range []string{"", "abc", "0000000000!", "zzzzzzzzzzz"} {
+		_, err := DeobfuscateID(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func BenchmarkObfuscateID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ObfuscateID(int64(i))
+	}
+}
@@ -0,0 +1,49 @@
+package buuid
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	objectIDOnce    sync.Once
+	objectIDProcess [5]byte
+	objectIDCounter atomic.Uint32
+)
+
+// ObjectID generates a MongoDB ObjectID as 24 lowercase hex characters: a 4-byte big-endian Unix
+// timestamp in seconds, a 5-byte random value chosen once per process and a 3-byte counter that starts
+// at a random value and increments with every ID, as in the MongoDB specification.
+// example: 6525d1a8f1e2d3c4b5000001
+func ObjectID() string {
+	objectIDOnce.Do(func() {
+		readRandom(objectIDProcess[:])
+		var b [4]byte
+		readRandom(b[:])
+		objectIDCounter.Store(binary.BigEndian.Uint32(b[:]))
+	})
+
+	var id [12]byte
+	binary.BigEndian.PutUint32(id[0:4], uint32(time.Now().Unix()))
+	copy(id[4:9], objectIDProcess[:])
+	c := objectIDCounter.Add(1)
+	id[9], id[10], id[11] = byte(c>>16), byte(c>>8), byte(c)
+
+	return hex.EncodeToString(id[:])
+}
+
+// ObjectIDTime returns the creation time encoded in an ObjectID, with second precision.
+func ObjectIDTime(s string) (time.Time, error) {
+	if len(s) != 24 {
+		return time.Time{}, errors.New("buuid: invalid ObjectID length")
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return time.Time{}, errors.New("buuid: invalid ObjectID hex")
+	}
+	return time.Unix(int64(binary.BigEndian.Uint32(b[0:4])), 0), nil
+}
@@ -0,0 +1,50 @@
+package buuid
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectID(t *testing.T) {
+	re := regexp.MustCompile(`^[0-9a-f]{24}$`)
+
+	before := time.Now().Truncate(time.Second)
+	prev := ""
+	for i := 0; i < 100; i++ {
+		s := ObjectID()
+		assert.Regexp(t, re, s)
+
+		tm, err := ObjectIDTime(s)
+		assert.NoError(t, err)
+		assert.False(t, tm.Before(before))
+		assert.False(t, tm.After(time.Now()))
+
+		if prev != "" {
+			// the process part is fixed and the counter increments
+			assert.Equal(t, prev[8:18], s[8:18])
+			a, _ := strconv.ParseUint(prev[18:], 16, 32)
+			b, _ := strconv.ParseUint(s[18:], 16, 32)
+			assert.Equal(t, (a+1)&0xffffff, b)
+		}
+		prev = s
+	}
+
+	tm, err := ObjectIDTime("507f1f77bcf86cd799439011")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0x507f1f77), tm.Unix())
+
+	_, err = ObjectIDTime("507f1f77")
+	assert.Error(t, err)
+	_, err = ObjectIDTime("507f1f77bcf86cd79943901z")
+	assert.Error(t, err)
+}
+
+func BenchmarkObjectID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ObjectID()
+	}
+}
@@ -0,0 +1,29 @@
+package buuid
+
+import (
+	"errors"
+	"strings"
+)
+
+// PathExtensions holds the file extensions RandomPath picks from, without the leading dot.
+var PathExtensions = []string{"txt", "log", "json", "csv", "md", "go", "png", "jpg", "tar.gz", "bin"}
+
+// RandomPath generates an absolute Unix-style path of depth segments, each 3 to 10 random lowercase
+// letters, the last segment being a file name with an extension from PathExtensions.
+// depth must be at least 1.
+// example: RandomPath(3) returns /qkfw/zoabmde/xjtr.json
+func RandomPath(depth int) (string, error) {
+	if depth < 1 {
+		return "", errors.New("buuid: depth must be at least 1")
+	}
+
+	var b strings.Builder
+	for i := 0; i < depth; i++ {
+		b.WriteByte('/')
+		WriteString(&b, R_LOWER, Int(3, 10))
+	}
+	b.WriteByte('.')
+	b.WriteString(PathExtensions[randIntn(len(PathExtensions))])
+
+	return b.String(), nil
+}
@@ -0,0 +1,42 @@
+package buuid
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomPath(t *testing.T) {
+	segment := regexp.MustCompile(`^[a-z]{3,10}$`)
+	for _, depth := range []int{1, 2, 5} {
+		for i := 0; i < 100; i++ {
+			p, err := RandomPath(depth)
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(p, "/"))
+			assert.Equal(t, p, path.Clean(p))
+
+			segments := strings.Split(p[1:], "/")
+			assert.Equal(t, depth, len(segments))
+
+			name, ext, ok := strings.Cut(segments[depth-1], ".")
+			assert.True(t, ok)
+			assert.Contains(t, PathExtensions, ext)
+			segments[depth-1] = name
+			for _, s := range segments {
+				assert.Regexp(t, segment, s)
+			}
+		}
+	}
+
+	_, err := RandomPath(0)
+	assert.Error(t, err)
+}
+
+func BenchmarkRandomPath(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = RandomPath(4)
+	}
+}
@@ -0,0 +1,74 @@
+package buuid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PhoneNumber generates a plausibly formatted mobile/subscriber phone number for region, for test data only,
+// numbers follow the structural rules of the region but are not checked against any assignment.
+// Supported regions (case-insensitive):
+//
+//	US: +1 NXX-NXX-XXXX (NANP, N is 2-9, neither part is N11)
+//	GB, UK: +44 7XXX XXXXXX (mobile range 74-79)
+//	TH: +66 8X XXX XXXX (mobile prefixes 6, 8 and 9)
+//	JP: +81 90-XXXX-XXXX (mobile prefixes 70, 80 and 90)
+func PhoneNumber(region string) (string, error) {
+	switch strings.ToUpper(region) {
+	case "US":
+		return "+1 " + nanpPart() + "-" + nanpPart() + "-" + String(R_NUM, 4), nil
+	case "GB", "UK":
+		return "+44 7" + strconv.Itoa(Int(4, 9)) + String(R_NUM, 2) + " " + String(R_NUM, 6), nil
+	case "TH":
+		return "+66 " + string("689"[randIntn(3)]) + String(R_NUM, 1) + " " + String(R_NUM, 3) + " " + String(R_NUM, 4), nil
+	case "JP":
+		return "+81 " + string("789"[randIntn(3)]) + "0-" + String(R_NUM, 4) + "-" + String(R_NUM, 4), nil
+	}
+	return "", fmt.Errorf("buuid: unsupported phone region %q", region)
+}
+
+// nanpPart generates a NANP area code or exchange: NXX where N is 2-9 and XX is not 11.
+func nanpPart() string {
+	for {
+		s := strconv.Itoa(Int(2, 9)) + String(R_NUM, 2)
+		if s[1:] != "11" {
+			return s
+		}
+	}
+}
+
+// FirstNames and LastNames are the lists FirstName, LastName and FullName draw from,
+// replace them to generate names from other lists.
+var (
+	FirstNames = []string{
+		"Aaron", "Alice", "Amara", "Ben", "Carlos", "Chloe", "Daniel", "Elena", "Ethan", "Fatima",
+		"Grace", "Hana", "Isaac", "Jack", "Julia", "Kenji", "Laura", "Liam", "Maya", "Noah",
+		"Olivia", "Omar", "Priya", "Quinn", "Rosa", "Sam", "Somchai", "Sofia", "Thomas", "Wei",
+	}
+	LastNames = []string{
+		"Anderson", "Brown", "Chen", "Davis", "Dubois", "Garcia", "Hernandez", "Ivanov", "Johnson", "Kim",
+		"Kowalski", "Lee", "Martin", "Müller", "Nguyen", "Okafor", "Patel", "Rossi", "Sato", "Silva",
+		"Smith", "Suzuki", "Taylor", "Thompson", "Wang", "Williams", "Wilson", "Wongsakul", "Yilmaz", "Zhang",
+	}
+)
+
+// FirstName returns a random name from FirstNames.
+func FirstName() string {
+	return FirstNames[randIntn(len(FirstNames))]
+}
+
+// LastName returns a random name from LastNames.
+func LastName() string {
+	return LastNames[randIntn(len(LastNames))]
+}
+
+// FullName returns a random "First Last" name, or "First M. Last" with a random middle initial
+// if middleInitial is true.
+// example: FullName() returns "Alice Chen", FullName(true) returns "Alice J. Chen"
+func FullName(middleInitial ...bool) string {
+	if len(middleInitial) > 0 && middleInitial[0] {
+		return FirstName() + " " + String(R_UPPER, 1) + ". " + LastName()
+	}
+	return FirstName() + " " + LastName()
+}
@@ -0,0 +1,65 @@
+package buuid
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoneNumber(t *testing.T) {
+	formats := map[string]*regexp.Regexp{
+		"US": regexp.MustCompile(`^\+1 [2-9]\d\d-[2-9]\d\d-\d{4}$`),
+		"us": regexp.MustCompile(`^\+1 [2-9]\d\d-[2-9]\d\d-\d{4}$`),
+		"GB": regexp.MustCompile(`^\+44 7[4-9]\d\d \d{6}$`),
+		"UK": regexp.MustCompile(`^\+44 7[4-9]\d\d \d{6}$`),
+		"TH": regexp.MustCompile(`^\+66 [689]\d \d{3} \d{4}$`),
+		"JP": regexp.MustCompile(`^\+81 [789]0-\d{4}-\d{4}$`),
+	}
+
+	for region, re := range formats {
+		for i := 0; i < 100; i++ {
+			s, err := PhoneNumber(region)
+			assert.NoError(t, err)
+			assert.Regexp(t, re, s)
+			if region == "US" {
+				assert.NotEqual(t, "11", s[4:6])
+				assert.NotEqual(t, "11", s[8:10])
+			}
+		}
+	}
+
+	_, err := PhoneNumber("XX")
+	assert.Error(t, err)
+}
+
+func TestFullName(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		assert.Contains(t, FirstNames, FirstName())
+		assert.Contains(t, LastNames, LastName())
+
+		parts := strings.Split(FullName(), " ")
+		assert.Equal(t, 2, len(parts))
+		assert.Contains(t, FirstNames, parts[0])
+		assert.Contains(t, LastNames, parts[1])
+
+		parts = strings.Split(FullName(true), " ")
+		assert.Equal(t, 3, len(parts))
+		assert.Contains(t, FirstNames, parts[0])
+		assert.Regexp(t, regexp.MustCompile(`^[A-Z]\.$`), parts[1])
+		assert.Contains(t, LastNames, parts[2])
+	}
+
+	// the lists can be replaced
+	first, last := FirstNames, LastNames
+	defer func() { FirstNames, LastNames = first, last }()
+	FirstNames, LastNames = []string{"Ada"}, []string{"Lovelace"}
+	assert.Equal(t, "Ada Lovelace", FullName())
+}
+
+func BenchmarkPhoneNumber(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = PhoneNumber("US")
+	}
+}
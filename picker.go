@@ -0,0 +1,175 @@
+package buuid
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// NonRepeatingPicker picks random items without returning the same item twice in a row,
+// items are compared by position, so equal values at different positions may follow each other.
+// It is safe for concurrent use.
+type NonRepeatingPicker[T any] struct {
+	mu    sync.Mutex
+	items []T
+	last  int
+}
+
+// NewNonRepeatingPicker creates a NonRepeatingPicker over a copy of items, at least 2 items are required.
+func NewNonRepeatingPicker[T any](items []T) (*NonRepeatingPicker[T], error) {
+	if len(items) < 2 {
+		return nil, errors.New("buuid: at least 2 items are required")
+	}
+	return &NonRepeatingPicker[T]{items: append([]T(nil), items...), last: -1}, nil
+}
+
+// Next returns a random item other than the previous one, uniform over the remaining items.
+func (p *NonRepeatingPicker[T]) Next() T {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var i int
+	if p.last < 0 {
+		i = randIntn(len(p.items))
+	} else {
+		// Draw among the other n-1 positions, skipping over the previous one.
+		i = randIntn(len(p.items) - 1)
+		if i >= p.last {
+			i++
+		}
+	}
+	p.last = i
+
+	return p.items[i]
+}
+
+// Rotating walks its items in a random order, every item is returned once per cycle
+// and the order is reshuffled when a cycle completes. It is safe for concurrent use.
+type Rotating[T any] struct {
+	mu    sync.Mutex
+	items []T
+	pos   int
+}
+
+// NewRotating creates a Rotating over a copy of items, at least 1 item is required.
+func NewRotating[T any](items []T) (*Rotating[T], error) {
+	if len(items) == 0 {
+		return nil, errors.New("buuid: at least 1 item is required")
+	}
+	return &Rotating[T]{items: append([]T(nil), items...)}, nil
+}
+
+// Next returns the next item of the current cycle, starting a freshly shuffled cycle as needed.
+func (r *Rotating[T]) Next() T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pos == 0 {
+		// Fisher-Yates shuffle
+		for i := len(r.items) - 1; i > 0; i-- {
+			j := randIntn(i + 1)
+			r.items[i], r.items[j] = r.items[j], r.items[i]
+		}
+	}
+	v := r.items[r.pos]
+	r.pos = (r.pos + 1) % len(r.items)
+
+	return v
+}
+
+// WindowedPicker picks random items avoiding every value returned within the last window picks,
+// reducing short-term repeats such as in shuffled playback. It is safe for concurrent use.
+type WindowedPicker[T comparable] struct {
+	mu     sync.Mutex
+	items  []T
+	window int
+	recent []T // the last picks, oldest first
+	counts map[T]int
+}
+
+// NewWindowedPicker creates a WindowedPicker over a copy of items, at least 1 item is required and
+// window must be non-negative. A window is only feasible up to the number of distinct values minus 1,
+// a larger window is reduced to it, so the picker degenerates to rotating through the values in a
+// fixed random order.
+func NewWindowedPicker[T comparable](items []T, window int) (*WindowedPicker[T], error) {
+	if len(items) == 0 {
+		return nil, errors.New("buuid: at least 1 item is required")
+	}
+	if window < 0 {
+		return nil, errors.New("buuid: window must be non-negative")
+	}
+
+	distinct := map[T]struct{}{}
+	for _, v := range items {
+		distinct[v] = struct{}{}
+	}
+
+	return &WindowedPicker[T]{
+		items:  append([]T(nil), items...),
+		window: min(window, len(distinct)-1),
+		counts: map[T]int{},
+	}, nil
+}
+
+// Next returns a random item whose value was not returned within the last window picks,
+// uniform over the positions of the remaining items.
+func (p *WindowedPicker[T]) Next() T {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Draw among the allowed positions without building a candidate list.
+	allowed := 0
+	for _, v := range p.items {
+		if p.counts[v] == 0 {
+			allowed++
+		}
+	}
+	k := randIntn(allowed)
+	var v T
+	for _, v = range p.items {
+		if p.counts[v] == 0 {
+			if k == 0 {
+				break
+			}
+			k--
+		}
+	}
+
+	if p.window > 0 {
+		if len(p.recent) == p.window {
+			old := p.recent[0]
+			p.recent = p.recent[1:]
+			if p.counts[old]--; p.counts[old] == 0 {
+				delete(p.counts, old)
+			}
+		}
+		p.recent = append(p.recent, v)
+		p.counts[v]++
+	}
+
+	return v
+}
+
+// PickConst returns a random value of values, typically the iota constants of an enum type,
+// values must not be empty.
+// example: PickConst([]State{Idle, Running, Stopped})
+func PickConst[T ~int](values []T) (T, error) {
+	if len(values) == 0 {
+		return 0, errors.New("buuid: values is empty")
+	}
+	return values[randIntn(len(values))], nil
+}
+
+// PickConstName returns a random value of values like PickConst together with its name, the result of
+// String if T implements fmt.Stringer and the decimal value otherwise.
+func PickConstName[T ~int](values []T) (T, string, error) {
+	v, err := PickConst(values)
+	if err != nil {
+		return 0, "", err
+	}
+	if s, ok := any(v).(fmt.Stringer); ok {
+		return v, s.String(), nil
+	}
+	return v, strconv.Itoa(int(v)), nil
+}
@@ -0,0 +1,180 @@
+package buuid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonRepeatingPicker(t *testing.T) {
+	items := []string{"a", "b", "c", "d"}
+	p, err := NewNonRepeatingPicker(items)
+	assert.NoError(t, err)
+
+	l := 40000
+	counts := map[string]int{}
+	prev := ""
+	for i := 0; i < l; i++ {
+		v := p.Next()
+		assert.NotEqual(t, prev, v)
+		counts[v]++
+		prev = v
+	}
+	for _, item := range items {
+		assert.InDelta(t, l/len(items), counts[item], float64(l/len(items))*0.1)
+	}
+
+	p2, err := NewNonRepeatingPicker([]int{1, 2})
+	assert.NoError(t, err)
+	first := p2.Next()
+	for i := 0; i < 10; i++ {
+		v := p2.Next()
+		assert.NotEqual(t, first, v)
+		first = v
+	}
+
+	_, err = NewNonRepeatingPicker([]int{1})
+	assert.Error(t, err)
+}
+
+func TestRotating(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6}
+	r, err := NewRotating(items)
+	assert.NoError(t, err)
+
+	orders := map[string]bool{}
+	for cycle := 0; cycle < 20; cycle++ {
+		seen := map[int]bool{}
+		order := ""
+		for range items {
+			v := r.Next()
+			assert.False(t, seen[v], "item repeated within a cycle")
+			seen[v] = true
+			order += string(rune('0' + v))
+		}
+		assert.Equal(t, len(items), len(seen))
+		orders[order] = true
+	}
+	// 20 cycles of 720 possible orders are not all the same
+	assert.Greater(t, len(orders), 1)
+
+	one, err := NewRotating([]string{"x"})
+	assert.NoError(t, err)
+	assert.Equal(t, "x", one.Next())
+	assert.Equal(t, "x", one.Next())
+
+	_, err = NewRotating([]int{})
+	assert.Error(t, err)
+}
+
+func TestWindowedPicker(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	for _, window := range []int{0, 1, 3, 9} {
+		p, err := NewWindowedPicker(items, window)
+		assert.NoError(t, err)
+
+		var picks []int
+		counts := map[int]int{}
+		for i := 0; i < 10000; i++ {
+			v := p.Next()
+			picks = append(picks, v)
+			counts[v]++
+		}
+		assert.Equal(t, len(items), len(counts))
+
+		// no value repeats within any window+1 consecutive picks
+		for i := range picks {
+			seen := map[int]bool{}
+			for _, v := range picks[i:min(i+window+1, len(picks))] {
+				assert.False(t, seen[v], "window %d repeat at %d", window, i)
+				seen[v] = true
+			}
+		}
+	}
+
+	// an oversized window rotates through the values in a fixed order
+	p, err := NewWindowedPicker([]string{"a", "b", "c", "a"}, 10)
+	assert.NoError(t, err)
+	first := []string{p.Next(), p.Next(), p.Next()}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first, []string{p.Next(), p.Next(), p.Next()})
+	}
+
+	one, err := NewWindowedPicker([]int{7}, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, one.Next())
+	assert.Equal(t, 7, one.Next())
+
+	_, err = NewWindowedPicker([]int{}, 1)
+	assert.Error(t, err)
+	_, err = NewWindowedPicker([]int{1, 2}, -1)
+	assert.Error(t, err)
+}
+
+type testState int
+
+const (
+	testIdle testState = iota
+	testRunning
+	testStopped
+)
+
+func (s testState) String() string {
+	return [...]string{"idle", "running", "stopped"}[s]
+}
+
+type testLevel int
+
+func TestPickConst(t *testing.T) {
+	states := []testState{testIdle, testRunning, testStopped}
+	counts := map[testState]int{}
+	for i := 0; i < 3000; i++ {
+		v, err := PickConst(states)
+		assert.NoError(t, err)
+		assert.Contains(t, states, v)
+		counts[v]++
+
+		v, name, err := PickConstName(states)
+		assert.NoError(t, err)
+		assert.Equal(t, v.String(), name)
+	}
+	assert.Equal(t, len(states), len(counts))
+
+	l, name, err := PickConstName([]testLevel{7})
+	assert.NoError(t, err)
+	assert.Equal(t, testLevel(7), l)
+	assert.Equal(t, "7", name)
+
+	_, err = PickConst([]testState{})
+	assert.Error(t, err)
+	_, _, err = PickConstName([]testLevel(nil))
+	assert.Error(t, err)
+}
+
+func BenchmarkNonRepeatingPicker_Next(b *testing.B) {
+	p, _ := NewNonRepeatingPicker([]int{1, 2, 3, 4, 5})
+	for i := 0; i < b.N; i++ {
+		p.Next()
+	}
+}
+
+func BenchmarkRotating_Next(b *testing.B) {
+	r, _ := NewRotating([]int{1, 2, 3, 4, 5})
+	for i := 0; i < b.N; i++ {
+		r.Next()
+	}
+}
+
+func BenchmarkPickConst(b *testing.B) {
+	states := []testState{testIdle, testRunning, testStopped}
+	for i := 0; i < b.N; i++ {
+		_, _ = PickConst(states)
+	}
+}
+
+func BenchmarkWindowedPicker_Next(b *testing.B) {
+	p, _ := NewWindowedPicker([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 3)
+	for i := 0; i < b.N; i++ {
+		p.Next()
+	}
+}
@@ -0,0 +1,123 @@
+package buuid
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strings"
+)
+
+// maxRegexRepeat caps the repetitions FromRegex adds for unbounded quantifiers: * and + and {n,}.
+const maxRegexRepeat = 8
+
+// FromRegex generates a random string matching the RE2 pattern.
+// Supported are literals, character classes (including \d, \w, \s and negation), ., alternation,
+// groups and the quantifiers *, +, ?, {n}, {n,} and {n,m}. Unbounded quantifiers repeat at most
+// maxRegexRepeat (8) times beyond their minimum. Anchors (^, $, \A, \z) are accepted and emit nothing,
+// so they only make sense at the ends of the pattern, word boundaries are not supported.
+// Classes and . prefer printable ASCII and fall back to other runes only if the class has no ASCII.
+// example: FromRegex(`[A-Z]{3}-\d{4}`)
+func FromRegex(pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", fmt.Errorf("buuid: %w", err)
+	}
+
+	var b strings.Builder
+	if err := genRegex(&b, re); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func genRegex(b *strings.Builder, re *syntax.Regexp) error {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return fmt.Errorf("buuid: pattern %q matches nothing", re.String())
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText:
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 && randIntn(2) == 1 {
+				r = foldRune(r)
+			}
+			b.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		r, ok := regexClassRune(re.Rune)
+		if !ok {
+			return fmt.Errorf("buuid: character class %s matches nothing", re.String())
+		}
+		b.WriteRune(r)
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		b.WriteRune(rune(Int(0x20, 0x7e)))
+	case syntax.OpCapture:
+		return genRegex(b, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := genRegex(b, sub); err != nil {
+				return err
+			}
+		}
+	case syntax.OpAlternate:
+		return genRegex(b, re.Sub[randIntn(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		lo, hi := 0, maxRegexRepeat
+		switch re.Op {
+		case syntax.OpPlus:
+			lo, hi = 1, 1+maxRegexRepeat
+		case syntax.OpQuest:
+			hi = 1
+		case syntax.OpRepeat:
+			lo, hi = re.Min, re.Max
+			if hi < 0 {
+				hi = lo + maxRegexRepeat
+			}
+		}
+		for n := Int(lo, hi); n > 0; n-- {
+			if err := genRegex(b, re.Sub[0]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("buuid: unsupported regex operator in %q", re.String())
+	}
+	return nil
+}
+
+// regexClassRune picks a random rune from a class given as inclusive [lo, hi] pairs,
+// preferring printable ASCII and never returning a surrogate.
+func regexClassRune(ranges []rune) (rune, bool) {
+	var ascii [][2]rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := max(ranges[i], 0x20), min(ranges[i+1], 0x7e)
+		if lo <= hi {
+			ascii = append(ascii, [2]rune{lo, hi})
+		}
+	}
+	if len(ascii) > 0 {
+		return []rune(UnicodeString(1, ascii...))[0], true
+	}
+
+	if len(ranges) == 0 {
+		return 0, false
+	}
+	all := make([][2]rune, 0, len(ranges)/2)
+	for i := 0; i+1 < len(ranges); i += 2 {
+		all = append(all, [2]rune{ranges[i], ranges[i+1]})
+	}
+	s := UnicodeString(1, all...)
+	if s == "" {
+		return 0, false
+	}
+	return []rune(s)[0], true
+}
+
+// foldRune returns another case of r, or r if it has none.
+func foldRune(r rune) rune {
+	if u := strings.ToUpper(string(r)); u != string(r) {
+		return []rune(u)[0]
+	}
+	if l := strings.ToLower(string(r)); l != string(r) {
+		return []rune(l)[0]
+	}
+	return r
+}
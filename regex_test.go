@@ -0,0 +1,48 @@
+package buuid
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromRegex(t *testing.T) {
+	patterns := []string{
+		`abc`,
+		`[A-Z]{3}-\d{4}`,
+		`^[a-f0-9]{8}$`,
+		`(foo|bar|baz)+`,
+		`colou?r`,
+		`\w+@\w+\.(com|org)`,
+		`[^a-z]{5}`,
+		`.{2,5}x*`,
+		`(?i)hello`,
+		`a{3,}b{0,2}`,
+		`[\p{Greek}]{4}`,
+		`\s\S\D`,
+		``,
+	}
+
+	for _, p := range patterns {
+		re := regexp.MustCompile(`^(?:` + p + `)$`)
+		for i := 0; i < 100; i++ {
+			s, err := FromRegex(p)
+			assert.NoError(t, err, p)
+			assert.Regexp(t, re, s, p)
+		}
+	}
+
+	_, err := FromRegex(`[`)
+	assert.Error(t, err)
+	_, err = FromRegex(`\bword\b`)
+	assert.Error(t, err)
+	_, err = FromRegex(`[^\x00-\x{10FFFF}]`)
+	assert.Error(t, err)
+}
+
+func BenchmarkFromRegex(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = FromRegex(`[A-Z]{3}-\d{4}`)
+	}
+}
@@ -0,0 +1,396 @@
+package buuid
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// AliasSampler draws indexes from a fixed weighted distribution in O(1) per draw,
+// using Walker's alias method. It is safe for concurrent use.
+type AliasSampler struct {
+	prob  []float64
+	alias []int
+}
+
+// NewAliasSampler builds the alias tables for weights in O(n), weights must be
+// non-negative and have a nonzero total, index i is drawn with probability weights[i]/sum(weights).
+func NewAliasSampler(weights []float64) (*AliasSampler, error) {
+	n := len(weights)
+	if n == 0 {
+		return nil, errors.New("buuid: weights is empty")
+	}
+
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, errors.New("buuid: weights must be non-negative and finite")
+		}
+		total += w
+	}
+	if total == 0 {
+		return nil, errors.New("buuid: total weight must be greater than 0")
+	}
+
+	s := &AliasSampler{
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+	}
+
+	// Scale the weights so that the average is 1, then pair each under-full
+	// column with an over-full one.
+	scaled := make([]float64, n)
+	small := make([]int, 0, n)
+	large := make([]int, 0, n)
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		l := small[len(small)-1]
+		small = small[:len(small)-1]
+		g := large[len(large)-1]
+		large = large[:len(large)-1]
+
+		s.prob[l] = scaled[l]
+		s.alias[l] = g
+
+		scaled[g] = scaled[g] + scaled[l] - 1
+		if scaled[g] < 1 {
+			small = append(small, g)
+		} else {
+			large = append(large, g)
+		}
+	}
+
+	// Whatever is left is full, up to floating point error.
+	for _, i := range large {
+		s.prob[i] = 1
+		s.alias[i] = i
+	}
+	for _, i := range small {
+		s.prob[i] = 1
+		s.alias[i] = i
+	}
+
+	return s, nil
+}
+
+// Next returns a random index drawn from the sampler's distribution.
+func (s *AliasSampler) Next() int {
+	i := randIntn(len(s.prob))
+	if randFloat() < s.prob[i] {
+		return i
+	}
+	return s.alias[i]
+}
+
+// Perm returns a random permutation of the integers [0, n) using Fisher-Yates shuffling,
+// an empty slice is returned if n <= 0.
+func Perm(n int) []int {
+	if n <= 0 {
+		return []int{}
+	}
+
+	p := make([]int, n)
+	for i := range p {
+		p[i] = i
+	}
+	for i := n - 1; i > 0; i-- {
+		j := randIntn(i + 1)
+		p[i], p[j] = p[j], p[i]
+	}
+
+	return p
+}
+
+// Sample returns k distinct elements of s chosen uniformly at random without replacement,
+// the order of the result is random and s is not modified.
+func Sample[T any](s []T, k int) ([]T, error) {
+	if k < 0 || k > len(s) {
+		return nil, fmt.Errorf("buuid: cannot sample %d elements from %d", k, len(s))
+	}
+
+	// Partial Fisher-Yates shuffle over the indexes, only the first k positions are drawn.
+	idx := make([]int, len(s))
+	for i := range idx {
+		idx[i] = i
+	}
+	result := make([]T, k)
+	for i := range result {
+		j := i + randIntn(len(s)-i)
+		idx[i], idx[j] = idx[j], idx[i]
+		result[i] = s[idx[i]]
+	}
+
+	return result, nil
+}
+
+// SampleQuota draws quotas[name] elements without replacement from each groups[name]
+// and concatenates the draws, ordered by group name.
+func SampleQuota[T any](groups map[string][]T, quotas map[string]int) ([]T, error) {
+	names := make([]string, 0, len(quotas))
+	total := 0
+	for name, q := range quotas {
+		names = append(names, name)
+		total += q
+	}
+	sort.Strings(names)
+
+	result := make([]T, 0, total)
+	for _, name := range names {
+		group, ok := groups[name]
+		if !ok {
+			return nil, fmt.Errorf("buuid: group %q does not exist", name)
+		}
+		s, err := Sample(group, quotas[name])
+		if err != nil {
+			return nil, fmt.Errorf("buuid: group %q: %w", name, err)
+		}
+		result = append(result, s...)
+	}
+
+	return result, nil
+}
+
+// subsequenceChunk is the number of elements Subsequence draws entropy for in one read.
+const subsequenceChunk = 512
+
+// Subsequence returns the elements of s each kept independently with probability p, in their original order,
+// p must be in [0, 1]. Entropy is read in bulk rather than per element.
+func Subsequence[T any](s []T, p float64) ([]T, error) {
+	if !(p >= 0 && p <= 1) {
+		return nil, errors.New("buuid: p must be in [0, 1]")
+	}
+
+	result := make([]T, 0, int(float64(len(s))*p))
+	buf := make([]byte, 8*min(len(s), subsequenceChunk))
+	for start := 0; start < len(s); start += subsequenceChunk {
+		end := min(start+subsequenceChunk, len(s))
+		readRandom(buf[:8*(end-start)])
+		for i := start; i < end; i++ {
+			v := binary.BigEndian.Uint64(buf[8*(i-start):])
+			if float64(v>>11)/(1<<53) < p {
+				result = append(result, s[i])
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// sortedDistinctInts returns k distinct random numbers from [0, n) in ascending order,
+// drawn with Floyd's algorithm, k must be in [0, n].
+func sortedDistinctInts(n, k int) []int {
+	chosen := make(map[int]struct{}, k)
+	result := make([]int, 0, k)
+	for j := n - k; j < n; j++ {
+		t := randIntn(j + 1)
+		if _, ok := chosen[t]; ok {
+			t = j
+		}
+		chosen[t] = struct{}{}
+		result = append(result, t)
+	}
+	sort.Ints(result)
+
+	return result
+}
+
+// sortedDistinctUint64s is like sortedDistinctInts for k distinct numbers from [0, last],
+// so the range can cover every uint64, k must be in [0, last+1].
+func sortedDistinctUint64s(last uint64, k int) []uint64 {
+	chosen := make(map[uint64]struct{}, k)
+	result := make([]uint64, 0, k)
+	for i := k - 1; i >= 0; i-- {
+		j := last - uint64(i)
+		var t uint64
+		if j == math.MaxUint64 {
+			var b [8]byte
+			readRandom(b[:])
+			t = binary.BigEndian.Uint64(b[:])
+		} else {
+			t = defaultGenerator.uint64n(j + 1)
+		}
+		if _, ok := chosen[t]; ok {
+			t = j
+		}
+		chosen[t] = struct{}{}
+		result = append(result, t)
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a] < result[b] })
+
+	return result
+}
+
+// SpacedInts returns count distinct numbers in [min, max] in ascending order, consecutive numbers differ
+// by at least minGap, a minGap below 1 is treated as 1. The result is uniform among all such sets: count
+// numbers are drawn from a range shrunk by (count-1)*(minGap-1) and spread back out by minGap-1 each.
+// min and max are swapped if min > max, an error is returned if count is negative or does not fit.
+// example: SpacedInts(5, 0, 100, 10)
+func SpacedInts(count, min, max, minGap int) ([]int, error) {
+	if count < 0 {
+		return nil, errors.New("buuid: count must be non-negative")
+	}
+	if min > max {
+		min, max = max, min
+	}
+	if minGap < 1 {
+		minGap = 1
+	}
+	if count == 0 {
+		return []int{}, nil
+	}
+
+	// The sizes are computed in uint64 as max-min can overflow int, the numbers fit
+	// if (count-1)*minGap <= max-min, checked by division to avoid overflowing too.
+	width := uint64(max) - uint64(min)
+	steps, gap := uint64(count-1), uint64(minGap-1)
+	if steps > 0 && uint64(minGap) > width/steps {
+		return nil, fmt.Errorf("buuid: %d numbers %d apart do not fit in [%d, %d]", count, minGap, min, max)
+	}
+
+	offsets := sortedDistinctUint64s(width-steps*gap, count)
+	result := make([]int, count)
+	for i, o := range offsets {
+		result[i] = min + int(o+uint64(i)*gap)
+	}
+
+	return result, nil
+}
+
+// PartitionInt returns parts non-negative numbers that sum to total, chosen uniformly among all
+// such compositions. It places parts-1 bars among total+parts-1 slots (stars and bars),
+// drawing the bar positions with Floyd's algorithm, so the cost does not depend on total.
+func PartitionInt(total, parts int) ([]int, error) {
+	if parts < 1 {
+		return nil, errors.New("buuid: parts must be at least 1")
+	}
+	if total < 0 {
+		return nil, errors.New("buuid: total must be non-negative")
+	}
+
+	n := total + parts - 1
+	bars := sortedDistinctInts(n, parts-1)
+
+	result := make([]int, parts)
+	prev := -1
+	for i, b := range bars {
+		result[i] = b - prev - 1
+		prev = b
+	}
+	result[parts-1] = n - prev - 1
+
+	return result, nil
+}
+
+// RecencyWeightedPick returns a random element of items favoring later ones: the element at index i
+// has weight 2^((i-(n-1))/halfLife), so the last element has weight 1 and every halfLife
+// positions toward the front halve the weight. halfLife must be positive and items non-empty.
+func RecencyWeightedPick[T any](items []T, halfLife int) (T, error) {
+	var zero T
+	if len(items) == 0 {
+		return zero, errors.New("buuid: items is empty")
+	}
+	if halfLife <= 0 {
+		return zero, errors.New("buuid: halfLife must be positive")
+	}
+
+	n := len(items)
+	weights := make([]float64, n)
+	total := 0.0
+	for i := range weights {
+		weights[i] = math.Exp2(float64(i-(n-1)) / float64(halfLife))
+		total += weights[i]
+	}
+
+	// Walk from the back, where most of the weight is.
+	target := randFloat() * total
+	for i := n - 1; i > 0; i-- {
+		target -= weights[i]
+		if target < 0 {
+			return items[i], nil
+		}
+	}
+	return items[0], nil
+}
+
+// SampleWithReplacement returns how many times each element of items is drawn in draws independent
+// uniform selections. The counts are drawn directly from the multinomial distribution, one binomial
+// per element, so the cost depends on len(items) rather than draws. Equal elements share a count,
+// elements never drawn are absent. items must be non-empty and draws non-negative.
+func SampleWithReplacement[T comparable](items []T, draws int) (map[T]int, error) {
+	if len(items) == 0 {
+		return nil, errors.New("buuid: items is empty")
+	}
+	if draws < 0 {
+		return nil, errors.New("buuid: draws must be non-negative")
+	}
+
+	counts := make(map[T]int)
+	remaining := draws
+	for i, item := range items {
+		if remaining == 0 {
+			break
+		}
+		// Of the draws left, each falls on this element with probability 1/(elements left).
+		c := remaining
+		if left := len(items) - i; left > 1 {
+			c = binomial(remaining, 1/float64(left))
+		}
+		if c > 0 {
+			counts[item] += c
+			remaining -= c
+		}
+	}
+
+	return counts, nil
+}
+
+// ErrAttemptsExhausted is returned by Until when no generated value satisfies the predicate.
+var ErrAttemptsExhausted = errors.New("buuid: attempts exhausted")
+
+// Until calls gen until pred accepts its value, at most maxAttempts times, the returned error
+// wraps ErrAttemptsExhausted if every value was rejected.
+// example: Until(func() int { return Int(1, 100) }, func(n int) bool { return n%7 == 0 }, 100)
+func Until[T any](gen func() T, pred func(T) bool, maxAttempts int) (T, error) {
+	var v T
+	for i := 0; i < maxAttempts; i++ {
+		v = gen()
+		if pred(v) {
+			return v, nil
+		}
+	}
+
+	var zero T
+	return zero, fmt.Errorf("%w: no value satisfied the predicate in %d attempts", ErrAttemptsExhausted, maxAttempts)
+}
+
+// Maybe returns nil with probability nullProb and a pointer to a value from gen otherwise,
+// modelling a nullable column, gen is not called for a nil result. nullProb must be in [0, 1].
+// example: Maybe(func() int { return Int(1, 100) }, 0.2)
+func Maybe[T any](gen func() T, nullProb float64) (*T, error) {
+	if !(nullProb >= 0 && nullProb <= 1) {
+		return nil, errors.New("buuid: nullProb must be in [0, 1]")
+	}
+	if randFloat() < nullProb {
+		return nil, nil
+	}
+
+	v := gen()
+	return &v, nil
+}
+
+// MaybeString returns nil with probability nullProb and a random string of kind and size otherwise,
+// see Maybe and String. nullProb must be in [0, 1].
+// example: MaybeString(R_All, 16, 0.1)
+func MaybeString(kind, size int, nullProb float64) (*string, error) {
+	return Maybe(func() string { return String(kind, size) }, nullProb)
+}
@@ -0,0 +1,371 @@
+package buuid
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAliasSampler(t *testing.T) {
+	weights := []float64{1, 0, 2, 7}
+	s, err := NewAliasSampler(weights)
+	assert.NoError(t, err)
+
+	l := 100000
+	counts := make([]int, len(weights))
+	for i := 0; i < l; i++ {
+		counts[s.Next()]++
+	}
+
+	assert.Equal(t, 0, counts[1])
+	for i, w := range weights {
+		assert.InDelta(t, w/10, float64(counts[i])/float64(l), 0.01)
+	}
+
+	_, err = NewAliasSampler(nil)
+	assert.Error(t, err)
+	_, err = NewAliasSampler([]float64{1, -1})
+	assert.Error(t, err)
+	_, err = NewAliasSampler([]float64{0, 0})
+	assert.Error(t, err)
+}
+
+func TestPerm(t *testing.T) {
+	assert.Equal(t, []int{}, Perm(0))
+	assert.Equal(t, []int{}, Perm(-1))
+
+	for i := 0; i < 100; i++ {
+		p := Perm(20)
+		seen := make([]bool, 20)
+		for _, v := range p {
+			assert.True(t, v >= 0 && v < 20)
+			assert.False(t, seen[v])
+			seen[v] = true
+		}
+	}
+
+	// each of the 6 permutations of 3 elements should be equally likely
+	l := 60000
+	counts := map[[3]int]int{}
+	for i := 0; i < l; i++ {
+		p := Perm(3)
+		counts[[3]int{p[0], p[1], p[2]}]++
+	}
+	assert.Equal(t, 6, len(counts))
+	for _, c := range counts {
+		assert.InDelta(t, l/6, c, float64(l/6)*0.1)
+	}
+}
+
+func TestSample(t *testing.T) {
+	s := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	for i := 0; i < 100; i++ {
+		r, err := Sample(s, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, len(r))
+
+		seen := map[int]bool{}
+		for _, v := range r {
+			assert.True(t, v >= 0 && v <= 9)
+			assert.False(t, seen[v])
+			seen[v] = true
+		}
+	}
+
+	r, err := Sample(s, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(r))
+	_, err = Sample(s, 11)
+	assert.Error(t, err)
+	_, err = Sample(s, -1)
+	assert.Error(t, err)
+}
+
+func TestSampleQuota(t *testing.T) {
+	groups := map[string][]string{
+		"admin": {"a1", "a2", "a3"},
+		"user":  {"u1", "u2", "u3", "u4", "u5"},
+		"guest": {"g1"},
+	}
+	quotas := map[string]int{"admin": 1, "user": 3, "guest": 1}
+
+	for i := 0; i < 100; i++ {
+		r, err := SampleQuota(groups, quotas)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, len(r))
+
+		// groups are concatenated in name order: admin, guest, user
+		assert.Contains(t, groups["admin"], r[0])
+		assert.Contains(t, groups["guest"], r[1])
+		for _, v := range r[2:] {
+			assert.Contains(t, groups["user"], v)
+		}
+	}
+
+	_, err := SampleQuota(groups, map[string]int{"guest": 2})
+	assert.Error(t, err)
+	_, err = SampleQuota(groups, map[string]int{"missing": 1})
+	assert.Error(t, err)
+}
+
+func TestSubsequence(t *testing.T) {
+	s := make([]int, 10000)
+	for i := range s {
+		s[i] = i
+	}
+
+	for _, p := range []float64{0, 0.1, 0.5, 1} {
+		r, err := Subsequence(s, p)
+		assert.NoError(t, err)
+		assert.InDelta(t, p*float64(len(s)), float64(len(r)), 300)
+		for i := 1; i < len(r); i++ {
+			assert.Less(t, r[i-1], r[i])
+		}
+	}
+
+	r, err := Subsequence(s, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, s, r)
+
+	empty, err := Subsequence([]string{}, 0.5)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(empty))
+
+	_, err = Subsequence(s, -0.1)
+	assert.Error(t, err)
+	_, err = Subsequence(s, 1.1)
+	assert.Error(t, err)
+}
+
+func TestPartitionInt(t *testing.T) {
+	for _, c := range [][2]int{{0, 1}, {0, 5}, {10, 1}, {10, 3}, {100, 10}, {1000000000, 4}} {
+		for i := 0; i < 100; i++ {
+			p, err := PartitionInt(c[0], c[1])
+			assert.NoError(t, err)
+			assert.Equal(t, c[1], len(p))
+
+			sum := 0
+			for _, v := range p {
+				assert.True(t, v >= 0)
+				sum += v
+			}
+			assert.Equal(t, c[0], sum)
+		}
+	}
+
+	// the 6 compositions of 2 into 3 parts are equally likely
+	l := 60000
+	counts := map[[3]int]int{}
+	for i := 0; i < l; i++ {
+		p, _ := PartitionInt(2, 3)
+		counts[[3]int{p[0], p[1], p[2]}]++
+	}
+	assert.Equal(t, 6, len(counts))
+	for _, c := range counts {
+		assert.InDelta(t, l/6, c, float64(l/6)*0.1)
+	}
+
+	_, err := PartitionInt(10, 0)
+	assert.Error(t, err)
+	_, err = PartitionInt(-1, 2)
+	assert.Error(t, err)
+}
+
+func TestRecencyWeightedPick(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4}
+	l := 100000
+	counts := make([]int, len(items))
+	for i := 0; i < l; i++ {
+		v, err := RecencyWeightedPick(items, 1)
+		assert.NoError(t, err)
+		counts[v]++
+	}
+
+	// weights are 1/16, 1/8, 1/4, 1/2, 1
+	total := 1.0 + 0.5 + 0.25 + 0.125 + 0.0625
+	for i := range items {
+		assert.InDelta(t, math.Exp2(float64(i-4))/total, float64(counts[i])/float64(l), 0.01)
+		if i > 0 {
+			assert.Greater(t, counts[i], counts[i-1])
+		}
+	}
+
+	v, err := RecencyWeightedPick([]string{"only"}, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "only", v)
+
+	_, err = RecencyWeightedPick([]int{}, 1)
+	assert.Error(t, err)
+	_, err = RecencyWeightedPick(items, 0)
+	assert.Error(t, err)
+}
+
+func TestSampleWithReplacement(t *testing.T) {
+	items := []string{"a", "b", "c", "d"}
+	for _, draws := range []int{0, 1, 10, 1000000} {
+		counts, err := SampleWithReplacement(items, draws)
+		assert.NoError(t, err)
+
+		sum := 0
+		for item, c := range counts {
+			assert.Contains(t, items, item)
+			sum += c
+		}
+		assert.Equal(t, draws, sum)
+	}
+
+	counts, _ := SampleWithReplacement(items, 1000000)
+	for _, item := range items {
+		assert.InDelta(t, 250000, counts[item], 2500)
+	}
+
+	// equal elements share a count
+	same, _ := SampleWithReplacement([]int{7, 7}, 100)
+	assert.Equal(t, map[int]int{7: 100}, same)
+
+	_, err := SampleWithReplacement([]int{}, 10)
+	assert.Error(t, err)
+	_, err = SampleWithReplacement([]int{1}, -1)
+	assert.Error(t, err)
+}
+
+func TestUntil(t *testing.T) {
+	// about 1 in 7 values is accepted
+	for i := 0; i < 100; i++ {
+		calls := 0
+		n, err := Until(func() int {
+			calls++
+			return Int(1, 70)
+		}, func(n int) bool { return n%7 == 0 }, 1000)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n%7)
+		assert.True(t, calls >= 1 && calls <= 1000)
+	}
+
+	calls := 0
+	s, err := Until(func() string {
+		calls++
+		return String(R_NUM)
+	}, func(string) bool { return false }, 5)
+	assert.ErrorIs(t, err, ErrAttemptsExhausted)
+	assert.Equal(t, "", s)
+	assert.Equal(t, 5, calls)
+
+	_, err = Until(func() int { return 1 }, func(int) bool { return true }, 0)
+	assert.ErrorIs(t, err, ErrAttemptsExhausted)
+}
+
+func TestSpacedInts(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		v, err := SpacedInts(5, 100, 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, len(v))
+		assert.True(t, v[0] >= 0 && v[4] <= 100)
+		for j := 1; j < len(v); j++ {
+			assert.True(t, v[j]-v[j-1] >= 10, "%v", v)
+		}
+	}
+
+	// the tightest fit has a single solution
+	v, err := SpacedInts(4, 0, 9, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 3, 6, 9}, v)
+
+	// every number of the range is reachable
+	seen := map[int]bool{}
+	for i := 0; i < 1000; i++ {
+		v, err := SpacedInts(2, 1, 10, 4)
+		assert.NoError(t, err)
+		seen[v[0]], seen[v[1]] = true, true
+	}
+	assert.Equal(t, 10, len(seen))
+
+	v, err = SpacedInts(3, 5, 7, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{5, 6, 7}, v)
+	v, err = SpacedInts(0, 5, 7, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(v))
+
+	_, err = SpacedInts(4, 0, 8, 3)
+	assert.Error(t, err)
+	_, err = SpacedInts(4, 0, 2, 1)
+	assert.Error(t, err)
+	_, err = SpacedInts(-1, 0, 10, 1)
+	assert.Error(t, err)
+
+	// max-min overflows int
+	v, err = SpacedInts(3, math.MinInt, math.MaxInt, math.MaxInt)
+	assert.NoError(t, err)
+	for j := 1; j < len(v); j++ {
+		assert.True(t, v[j] > v[j-1] && uint64(v[j]-v[j-1]) >= math.MaxInt, "%v", v)
+	}
+	v, err = SpacedInts(2, math.MaxInt, math.MinInt, 1)
+	assert.NoError(t, err)
+	assert.True(t, v[0] < v[1], "%v", v)
+	_, err = SpacedInts(3, math.MinInt+1, math.MaxInt-1, math.MaxInt)
+	assert.Error(t, err)
+}
+
+func TestMaybe(t *testing.T) {
+	l, nulls := 100000, 0
+	for i := 0; i < l; i++ {
+		s, err := MaybeString(R_NUM, 8, 0.3)
+		assert.NoError(t, err)
+		if s == nil {
+			nulls++
+			continue
+		}
+		assert.Equal(t, 8, len(*s))
+	}
+	assert.InDelta(t, 0.3, float64(nulls)/float64(l), 0.01)
+
+	for i := 0; i < 100; i++ {
+		n, err := Maybe(func() int { return Int(1, 10) }, 0)
+		assert.NoError(t, err)
+		assert.NotNil(t, n)
+		assert.True(t, *n >= 1 && *n <= 10)
+
+		n, err = Maybe(func() int {
+			t.Fatal("gen called for a nil result")
+			return 0
+		}, 1)
+		assert.NoError(t, err)
+		assert.Nil(t, n)
+	}
+
+	_, err := MaybeString(R_NUM, 8, -0.1)
+	assert.Error(t, err)
+	_, err = MaybeString(R_NUM, 8, 1.1)
+	assert.Error(t, err)
+	_, err = Maybe(func() int { return 1 }, math.NaN())
+	assert.Error(t, err)
+}
+
+func BenchmarkAliasSampler_Next(b *testing.B) {
+	s, _ := NewAliasSampler([]float64{1, 2, 3, 4, 5, 6, 7, 8})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Next()
+	}
+}
+
+func BenchmarkPerm_100(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Perm(100)
+	}
+}
+
+func BenchmarkMaybeString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = MaybeString(R_All, 16, 0.1)
+	}
+}
+
+func BenchmarkSpacedInts(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = SpacedInts(10, 0, 10000, 100)
+	}
+}
@@ -0,0 +1,181 @@
+package buuid
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// FromJSONSchema generates a random JSON document conforming to schema. The supported keywords are:
+//
+//	type                 one of object, array, string, integer, number, boolean and null
+//	properties, required object members, optional members are included at random
+//	items, minItems, maxItems     array elements, default 0~5 elements
+//	minLength, maxLength string length, default 0~10 more than minLength
+//	minimum, maximum     inclusive bounds for integer and number, default 0~100 more than minimum
+//	enum                 a random value of the list, taking precedence over type
+//
+// Other keywords are ignored, so the output may not satisfy them.
+func FromJSONSchema(schema []byte) ([]byte, error) {
+	var s map[string]any
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return nil, fmt.Errorf("buuid: invalid schema: %w", err)
+	}
+
+	v, err := genSchema(s, "$")
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+func genSchema(s map[string]any, path string) (any, error) {
+	if enum, ok := s["enum"]; ok {
+		values, ok := enum.([]any)
+		if !ok || len(values) == 0 {
+			return nil, fmt.Errorf("buuid: %s: enum must be a non-empty array", path)
+		}
+		return values[randIntn(len(values))], nil
+	}
+
+	typ, _ := s["type"].(string)
+	switch typ {
+	case "null":
+		return nil, nil
+	case "boolean":
+		return randIntn(2) == 1, nil
+	case "integer":
+		lo, hi, err := schemaRange(s, "minimum", "maximum", 0, 100, schemaInteger, path)
+		if err != nil {
+			return nil, err
+		}
+		return Int(int(lo), int(hi)), nil
+	case "number":
+		lo, hi, err := schemaRange(s, "minimum", "maximum", 0, 100, schemaNumber, path)
+		if err != nil {
+			return nil, err
+		}
+		return lo + randFloat()*(hi-lo), nil
+	case "string":
+		lo, hi, err := schemaRange(s, "minLength", "maxLength", 0, 10, schemaCount, path)
+		if err != nil {
+			return nil, err
+		}
+		n := Int(int(lo), int(hi))
+		if n == 0 {
+			return "", nil
+		}
+		return String(R_All, n), nil
+	case "array":
+		lo, hi, err := schemaRange(s, "minItems", "maxItems", 0, 5, schemaCount, path)
+		if err != nil {
+			return nil, err
+		}
+		items, _ := s["items"].(map[string]any)
+		if items == nil {
+			return nil, fmt.Errorf("buuid: %s: array requires an items schema", path)
+		}
+		n := Int(int(lo), int(hi))
+		arr := make([]any, n)
+		for i := range arr {
+			if arr[i], err = genSchema(items, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	case "object":
+		props, _ := s["properties"].(map[string]any)
+		required := map[string]bool{}
+		if req, ok := s["required"].([]any); ok {
+			for _, r := range req {
+				name, _ := r.(string)
+				if _, ok := props[name]; !ok {
+					return nil, fmt.Errorf("buuid: %s: required property %q has no schema", path, name)
+				}
+				required[name] = true
+			}
+		}
+
+		names := make([]string, 0, len(props))
+		for name := range props {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		obj := make(map[string]any, len(props))
+		for _, name := range names {
+			if !required[name] && randIntn(2) == 0 {
+				continue
+			}
+			sub, ok := props[name].(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("buuid: %s.%s: schema must be an object", path, name)
+			}
+			v, err := genSchema(sub, path+"."+name)
+			if err != nil {
+				return nil, err
+			}
+			obj[name] = v
+		}
+		return obj, nil
+	}
+
+	return nil, fmt.Errorf("buuid: %s: unsupported type %v", path, s["type"])
+}
+
+// schemaBound is the kind of value a pair of schema bounds constrains.
+type schemaBound int
+
+const (
+	schemaNumber  schemaBound = iota // any number
+	schemaInteger                    // an int, the bounds are rounded inward to integers
+	schemaCount                      // a length or an item count, the bounds must be non-negative integers
+)
+
+// schemaIntLimit is 2^(strconv.IntSize-1), integers in [-schemaIntLimit, schemaIntLimit) fit in an int.
+var schemaIntLimit = math.Ldexp(1, strconv.IntSize-1)
+
+// schemaRange reads the inclusive bounds minKey and maxKey of s, if a bound is missing
+// the minimum defaults to defMin and the maximum to the minimum plus defSpan.
+// For schemaInteger and schemaCount the returned bounds are integers that fit in an int.
+func schemaRange(s map[string]any, minKey, maxKey string, defMin, defSpan float64, kind schemaBound, path string) (float64, float64, error) {
+	lo, hi := defMin, math.NaN()
+	if v, ok := s[minKey]; ok {
+		f, ok := v.(float64)
+		if !ok {
+			return 0, 0, fmt.Errorf("buuid: %s: %s must be a number", path, minKey)
+		}
+		lo = f
+	}
+	if v, ok := s[maxKey]; ok {
+		f, ok := v.(float64)
+		if !ok {
+			return 0, 0, fmt.Errorf("buuid: %s: %s must be a number", path, maxKey)
+		}
+		hi = f
+	}
+	if math.IsNaN(hi) {
+		hi = lo + defSpan
+	}
+	if lo > hi {
+		return 0, 0, fmt.Errorf("buuid: %s: %s is greater than %s", path, minKey, maxKey)
+	}
+
+	switch kind {
+	case schemaInteger:
+		lo, hi = math.Ceil(lo), math.Floor(hi)
+		if lo > hi {
+			return 0, 0, fmt.Errorf("buuid: %s: no integer within the bounds", path)
+		}
+	case schemaCount:
+		if lo < 0 || lo != math.Trunc(lo) || hi != math.Trunc(hi) {
+			return 0, 0, fmt.Errorf("buuid: %s: %s and %s must be non-negative integers", path, minKey, maxKey)
+		}
+	}
+	if kind != schemaNumber && (lo < -schemaIntLimit || hi >= schemaIntLimit) {
+		return 0, 0, fmt.Errorf("buuid: %s: %s and %s must fit in an int", path, minKey, maxKey)
+	}
+	return lo, hi, nil
+}
@@ -0,0 +1,152 @@
+package buuid
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// validateSchema reports whether v conforms to the keywords supported by FromJSONSchema.
+func validateSchema(s map[string]any, v any) bool {
+	if enum, ok := s["enum"].([]any); ok {
+		for _, e := range enum {
+			if assert.ObjectsAreEqual(e, v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	num := func(key string, def float64) float64 {
+		if f, ok := s[key].(float64); ok {
+			return f
+		}
+		return def
+	}
+
+	switch s["type"] {
+	case "null":
+		return v == nil
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "integer", "number":
+		f, ok := v.(float64)
+		if !ok || f < num("minimum", math.Inf(-1)) || f > num("maximum", math.Inf(1)) {
+			return false
+		}
+		return s["type"] == "number" || f == math.Trunc(f)
+	case "string":
+		str, ok := v.(string)
+		n := float64(utf8.RuneCountInString(str))
+		return ok && n >= num("minLength", 0) && n <= num("maxLength", math.Inf(1))
+	case "array":
+		arr, ok := v.([]any)
+		if !ok || float64(len(arr)) < num("minItems", 0) || float64(len(arr)) > num("maxItems", math.Inf(1)) {
+			return false
+		}
+		for _, e := range arr {
+			if !validateSchema(s["items"].(map[string]any), e) {
+				return false
+			}
+		}
+		return true
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return false
+		}
+		props, _ := s["properties"].(map[string]any)
+		for _, r := range s["required"].([]any) {
+			if _, ok := obj[r.(string)]; !ok {
+				return false
+			}
+		}
+		for name, value := range obj {
+			sub, ok := props[name].(map[string]any)
+			if !ok || !validateSchema(sub, value) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func TestFromJSONSchema(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"required": ["id", "name", "tags", "status"],
+		"properties": {
+			"id": {"type": "integer", "minimum": 1, "maximum": 1000},
+			"name": {"type": "string", "minLength": 3, "maxLength": 12},
+			"score": {"type": "number", "minimum": -1.5, "maximum": 1.5},
+			"active": {"type": "boolean"},
+			"deleted": {"type": "null"},
+			"status": {"enum": ["new", "open", "closed", 3]},
+			"tags": {"type": "array", "minItems": 1, "maxItems": 4, "items": {"type": "string", "maxLength": 5}},
+			"owner": {
+				"type": "object",
+				"required": ["email"],
+				"properties": {"email": {"type": "string", "minLength": 5}}
+			}
+		}
+	}`)
+
+	var s map[string]any
+	assert.NoError(t, json.Unmarshal(schema, &s))
+
+	for i := 0; i < 200; i++ {
+		doc, err := FromJSONSchema(schema)
+		assert.NoError(t, err)
+
+		var v any
+		assert.NoError(t, json.Unmarshal(doc, &v))
+		assert.True(t, validateSchema(s, v), string(doc))
+	}
+
+	// large integer bounds stay within the bounds
+	for i := 0; i < 100; i++ {
+		doc, err := FromJSONSchema([]byte(`{"type": "integer", "minimum": 0, "maximum": 1e18}`))
+		assert.NoError(t, err)
+		n, err := strconv.ParseInt(string(doc), 10, 64)
+		assert.NoError(t, err)
+		assert.True(t, n >= 0 && n <= 1e18, n)
+	}
+
+	invalid := []string{
+		`not json`,
+		`{}`,
+		`{"type": "widget"}`,
+		`{"enum": []}`,
+		`{"type": "integer", "minimum": 10, "maximum": 1}`,
+		`{"type": "integer", "minimum": 1.2, "maximum": 1.8}`,
+		`{"type": "string", "minLength": "3"}`,
+		`{"type": "string", "minLength": -1}`,
+		`{"type": "string", "maxLength": 2.5}`,
+		`{"type": "string", "minLength": 1.5, "maxLength": 3}`,
+		`{"type": "array", "items": {"type": "null"}, "minItems": -3}`,
+		`{"type": "array", "items": {"type": "null"}, "maxItems": 1.5}`,
+		`{"type": "array", "items": {"type": "null"}, "maxItems": 1e30}`,
+		`{"type": "integer", "minimum": 0, "maximum": 1e30}`,
+		`{"type": "integer", "minimum": -1e30, "maximum": 0}`,
+		`{"type": "integer", "minimum": 9223372036854775807}`,
+		`{"type": "array"}`,
+		`{"type": "object", "required": ["a"]}`,
+	}
+	for _, schema := range invalid {
+		_, err := FromJSONSchema([]byte(schema))
+		assert.Error(t, err, schema)
+	}
+}
+
+func BenchmarkFromJSONSchema(b *testing.B) {
+	schema := []byte(`{"type":"object","required":["id","name"],"properties":{"id":{"type":"integer"},"name":{"type":"string"}}}`)
+	for i := 0; i < b.N; i++ {
+		_, _ = FromJSONSchema(schema)
+	}
+}
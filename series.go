@@ -0,0 +1,54 @@
+package buuid
+
+import (
+	"errors"
+	"math"
+)
+
+// SeasonalSeries generates n points of a seasonal signal with additive Gaussian noise,
+// point i is amplitude*sin(2*pi*i/period) + noise*N(0, 1), period must be positive.
+func SeasonalSeries(n, period int, amplitude, noise float64) ([]float64, error) {
+	if period <= 0 {
+		return nil, errors.New("buuid: period must be positive")
+	}
+	if n < 0 {
+		return nil, errors.New("buuid: n must be non-negative")
+	}
+
+	series := make([]float64, n)
+	for i := range series {
+		series[i] = amplitude * math.Sin(2*math.Pi*float64(i)/float64(period))
+		if noise != 0 {
+			series[i] += noise * randNorm()
+		}
+	}
+
+	return series, nil
+}
+
+// MonotonicFloats generates n strictly increasing values, the first is start plus a step and each
+// further value adds another step. Steps are exponentially distributed with mean avgStep,
+// like the gaps between events of a Poisson process, a step that would not advance
+// the float64 value is rounded up to the next representable number. avgStep must be positive.
+// example: MonotonicFloats(100, 0, 1.5)
+func MonotonicFloats(n int, start, avgStep float64) ([]float64, error) {
+	if n < 0 {
+		return nil, errors.New("buuid: n must be non-negative")
+	}
+	if !(avgStep > 0) || math.IsInf(avgStep, 1) {
+		return nil, errors.New("buuid: avgStep must be positive")
+	}
+
+	values := make([]float64, n)
+	prev := start
+	for i := range values {
+		v := prev - avgStep*math.Log(1-randFloat())
+		if v <= prev {
+			v = math.Nextafter(prev, math.Inf(1))
+		}
+		values[i] = v
+		prev = v
+	}
+
+	return values, nil
+}
@@ -0,0 +1,84 @@
+package buuid
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeasonalSeries(t *testing.T) {
+	s, err := SeasonalSeries(100, 24, 3, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, len(s))
+	for i, v := range s {
+		assert.InDelta(t, 3*math.Sin(2*math.Pi*float64(i)/24), v, 1e-12)
+	}
+
+	// the residuals of a noisy series have the requested standard deviation
+	n := 20000
+	s, err = SeasonalSeries(n, 50, 10, 2)
+	assert.NoError(t, err)
+	sum, sumSq := 0.0, 0.0
+	for i, v := range s {
+		r := v - 10*math.Sin(2*math.Pi*float64(i)/50)
+		sum += r
+		sumSq += r * r
+	}
+	mean := sum / float64(n)
+	assert.InDelta(t, 0, mean, 0.1)
+	assert.InDelta(t, 2, math.Sqrt(sumSq/float64(n)-mean*mean), 0.1)
+
+	s, err = SeasonalSeries(0, 1, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(s))
+
+	_, err = SeasonalSeries(10, 0, 1, 0)
+	assert.Error(t, err)
+	_, err = SeasonalSeries(-1, 10, 1, 0)
+	assert.Error(t, err)
+}
+
+func TestMonotonicFloats(t *testing.T) {
+	n, start, step := 100000, 10.0, 2.5
+	values, err := MonotonicFloats(n, start, step)
+	assert.NoError(t, err)
+	assert.Equal(t, n, len(values))
+	assert.True(t, values[0] > start)
+	for i := 1; i < n; i++ {
+		if values[i] <= values[i-1] {
+			t.Fatalf("values[%d]=%v is not greater than values[%d]=%v", i, values[i], i-1, values[i-1])
+		}
+	}
+	assert.InDelta(t, step, (values[n-1]-start)/float64(n), step*0.02)
+
+	// steps far below the spacing of floats still advance
+	values, err = MonotonicFloats(100, 1e20, 1e-10)
+	assert.NoError(t, err)
+	for i := 1; i < len(values); i++ {
+		assert.True(t, values[i] > values[i-1])
+	}
+
+	values, err = MonotonicFloats(0, 0, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(values))
+
+	_, err = MonotonicFloats(-1, 0, 1)
+	assert.Error(t, err)
+	_, err = MonotonicFloats(10, 0, 0)
+	assert.Error(t, err)
+	_, err = MonotonicFloats(10, 0, math.NaN())
+	assert.Error(t, err)
+}
+
+func BenchmarkSeasonalSeries_1000(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = SeasonalSeries(1000, 24, 1, 0.1)
+	}
+}
+
+func BenchmarkMonotonicFloats_1000(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = MonotonicFloats(1000, 0, 1)
+	}
+}
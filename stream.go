@@ -0,0 +1,117 @@
+package buuid
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// WeightedBool returns true with probability p, p is clamped to [0, 1].
+func WeightedBool(p float64) bool {
+	return randFloat() < p
+}
+
+// WeightedStream emits n booleans on the returned channel, each true with probability p,
+// values are generated lazily as they are read and the channel is closed after n values
+// or when ctx is done, so the producing goroutine exits even if the consumer stops reading.
+func WeightedStream(ctx context.Context, p float64, n int) <-chan bool {
+	ch := make(chan bool)
+
+	go func() {
+		defer close(ch)
+		for i := 0; i < n; i++ {
+			select {
+			case ch <- WeightedBool(p):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// IDStream pre-generates IDs in the format of NewID into a channel buffered for buffer IDs, so consumers
+// get an ID without waiting. IDs are strictly increasing: an ID not above the previous one is replaced
+// by the previous one plus 1. The channel is closed when ctx is done, which stops the producing goroutine
+// even if nobody reads, a negative buffer is treated as 0.
+// example: ids := IDStream(ctx, 1024); id := <-ids
+func IDStream(ctx context.Context, buffer int) <-chan int64 {
+	ch := make(chan int64, max(buffer, 0))
+
+	go func() {
+		defer close(ch)
+		var last int64
+		for {
+			id := NewID()
+			if id <= last {
+				id = last + 1
+			}
+			last = id
+
+			select {
+			case ch <- id:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// LimitedGen generates string IDs no faster than a configured rate. It is safe for concurrent use.
+type LimitedGen struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// RateLimited creates a LimitedGen emitting at most perSecond IDs per second, perSecond must be in
+// [1, 1e9] as the interval between IDs is a whole number of nanoseconds.
+// It is a token bucket holding a single token refilled every 1/perSecond, so there are no bursts.
+func RateLimited(perSecond int) (*LimitedGen, error) {
+	if perSecond <= 0 {
+		return nil, errors.New("buuid: perSecond must be positive")
+	}
+	if perSecond > int(time.Second) {
+		return nil, errors.New("buuid: perSecond must be at most 1e9")
+	}
+	return &LimitedGen{interval: time.Second / time.Duration(perSecond)}, nil
+}
+
+// Next blocks until the rate allows another ID, then returns NewStringID().
+func (l *LimitedGen) Next() string {
+	s, _ := l.NextContext(context.Background())
+	return s
+}
+
+// NextContext is like Next, but returns ctx.Err() if ctx is done before the rate allows another ID.
+func (l *LimitedGen) NextContext(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	// Reserve the next slot, a canceled wait does not give it back.
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	if d := slot.Sub(now); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	return NewStringID(), nil
+}
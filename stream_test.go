@@ -0,0 +1,143 @@
+package buuid
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeightedBool(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		assert.False(t, WeightedBool(0))
+		assert.True(t, WeightedBool(1))
+	}
+}
+
+func TestWeightedStream(t *testing.T) {
+	n := 20000
+	count, trues := 0, 0
+	for v := range WeightedStream(context.Background(), 0.3, n) {
+		count++
+		if v {
+			trues++
+		}
+	}
+	assert.Equal(t, n, count)
+	assert.InDelta(t, 0.3, float64(trues)/float64(n), 0.02)
+}
+
+func TestWeightedStream_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := WeightedStream(ctx, 0.5, 1000000)
+
+	for i := 0; i < 10; i++ {
+		<-ch
+	}
+	cancel()
+
+	// the producer closes the channel once it observes the cancellation
+	done := make(chan struct{})
+	go func() {
+		for range ch {
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("stream was not closed after cancel")
+	}
+}
+
+func TestIDStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := IDStream(ctx, 64)
+
+	prev := int64(0)
+	for i := 0; i < 10000; i++ {
+		id := <-ch
+		if id <= prev {
+			t.Fatalf("id %d is not greater than the previous %d", id, prev)
+		}
+		prev = id
+	}
+	cancel()
+
+	// the producer closes the channel once it observes the cancellation
+	done := make(chan struct{})
+	go func() {
+		for range ch {
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("stream was not closed after cancel")
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	l, err := RateLimited(100)
+	assert.NoError(t, err)
+
+	window := 200 * time.Millisecond
+	start := time.Now()
+	count := 0
+	for time.Since(start) < window {
+		assert.Equal(t, 16, len(l.Next()))
+		count++
+	}
+	// one ID is allowed immediately, then one every 10ms
+	assert.LessOrEqual(t, count, 100*int(window/time.Millisecond)/1000+2)
+	assert.GreaterOrEqual(t, count, 10)
+
+	_, err = RateLimited(0)
+	assert.Error(t, err)
+
+	// the interval is at least 1ns, a higher rate would not limit at all
+	l, err = RateLimited(int(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Nanosecond, l.interval)
+	_, err = RateLimited(int(time.Second) + 1)
+	assert.Error(t, err)
+}
+
+func TestRateLimited_Cancel(t *testing.T) {
+	l, err := RateLimited(1)
+	assert.NoError(t, err)
+
+	_, err = l.NextContext(context.Background())
+	assert.NoError(t, err)
+
+	// the next slot is a second away
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = l.NextContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	_, err = l.NextContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func BenchmarkWeightedBool(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		WeightedBool(0.5)
+	}
+}
+
+func BenchmarkIDStream(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := IDStream(ctx, 1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		<-ch
+	}
+}
@@ -0,0 +1,263 @@
+package buuid
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// maxRepeatRetries bounds how many times StringNoRepeat redraws a character equal to its predecessor.
+const maxRepeatRetries = 8
+
+// StringNoRepeat generates a random string in which no two adjacent characters are identical,
+// default length is 6 if size <= 0. A draw equal to the previous character is resampled,
+// every kind has at least 10 characters so the redraw always terminates.
+// example: StringNoRepeat(R_NUM, 8)
+func StringNoRepeat(kind, size int) string {
+	if size <= 0 {
+		size = 6
+	}
+
+	chars := kindChars(kind)
+	n := len(chars)
+
+	result := make([]byte, size)
+	prev := -1
+	for i := range result {
+		c := randIntn(n)
+		for retry := 0; c == prev && retry < maxRepeatRetries; retry++ {
+			c = randIntn(n)
+		}
+		if c == prev {
+			// Retries exhausted, pick uniformly among the other n-1 characters.
+			c = (prev + 1 + randIntn(n-1)) % n
+		}
+		result[i] = chars[c]
+		prev = c
+	}
+
+	return string(result)
+}
+
+// WeightedAlphabet generates strings from a custom alphabet in which each rune
+// is drawn with probability proportional to its weight. It is safe for concurrent use.
+type WeightedAlphabet struct {
+	runes   []rune
+	sampler *AliasSampler
+}
+
+// NewWeightedAlphabet creates a WeightedAlphabet, runes and weights must have the same
+// non-zero length and every weight must be positive.
+// example: NewWeightedAlphabet([]rune("aeiou"), []int{8, 12, 7, 7, 3})
+func NewWeightedAlphabet(runes []rune, weights []int) (*WeightedAlphabet, error) {
+	if len(runes) != len(weights) {
+		return nil, errors.New("buuid: runes and weights must have the same length")
+	}
+
+	fw := make([]float64, len(weights))
+	for i, w := range weights {
+		if w <= 0 {
+			return nil, errors.New("buuid: weights must be positive")
+		}
+		fw[i] = float64(w)
+	}
+
+	sampler, err := NewAliasSampler(fw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WeightedAlphabet{
+		runes:   append([]rune(nil), runes...),
+		sampler: sampler,
+	}, nil
+}
+
+// String generates a random string of size runes, default length is 6 if size <= 0.
+func (a *WeightedAlphabet) String(size int) string {
+	if size <= 0 {
+		size = 6
+	}
+
+	result := make([]rune, size)
+	for i := range result {
+		result[i] = a.runes[a.sampler.Next()]
+	}
+
+	return string(result)
+}
+
+// UTF-16 surrogate halves, which are not valid in UTF-8.
+const (
+	surrogateMin = 0xD800
+	surrogateMax = 0xDFFF
+)
+
+// defaultUnicodeRanges mixes accented Latin, CJK ideographs and emoji.
+var defaultUnicodeRanges = [][2]rune{
+	{0x00C0, 0x024F},   // Latin-1 Supplement and Latin Extended-A/B letters
+	{0x4E00, 0x9FFF},   // CJK Unified Ideographs
+	{0x1F600, 0x1F64F}, // Emoticons
+}
+
+// UnicodeString generates a random valid UTF-8 string of size runes, default length is 6 if size <= 0.
+// Runes are drawn uniformly from the union of the inclusive ranges, which default to a mix of Latin,
+// CJK and emoji. Surrogates and values above unicode.MaxRune are excluded,
+// an empty string is returned if no valid rune remains.
+// example: UnicodeString(10), UnicodeString(10, [2]rune{'ก', '๙'})
+func UnicodeString(size int, ranges ...[2]rune) string {
+	if size <= 0 {
+		size = 6
+	}
+	if len(ranges) == 0 {
+		ranges = defaultUnicodeRanges
+	}
+
+	// Split the ranges into segments of valid scalar values.
+	segments := make([][2]rune, 0, len(ranges)+1)
+	total := 0
+	add := func(lo, hi rune) {
+		if lo <= hi {
+			segments = append(segments, [2]rune{lo, hi})
+			total += int(hi - lo + 1)
+		}
+	}
+	for _, r := range ranges {
+		lo, hi := r[0], r[1]
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		lo, hi = max(lo, 0), min(hi, unicode.MaxRune)
+		add(lo, min(hi, surrogateMin-1))
+		add(max(lo, surrogateMax+1), hi)
+	}
+	if total == 0 {
+		return ""
+	}
+
+	result := make([]rune, size)
+	for i := range result {
+		n := randIntn(total)
+		for _, s := range segments {
+			width := int(s[1] - s[0] + 1)
+			if n < width {
+				result[i] = s[0] + rune(n)
+				break
+			}
+			n -= width
+		}
+	}
+
+	return string(result)
+}
+
+// graphemeModifierBases are emoji that accept a skin tone modifier, U+1F3FB to U+1F3FF.
+var graphemeModifierBases = []rune{0x1F44B, 0x1F44D, 0x1F44F, 0x1F64C, 0x1F64F, 0x1F476, 0x1F9D1, 0x270C}
+
+// graphemeZWJSequences are emoji joined by U+200D ZERO WIDTH JOINER into a single grapheme.
+var graphemeZWJSequences = []string{
+	"\U0001F468\u200D\U0001F469\u200D\U0001F467", // family: man, woman, girl
+	"\U0001F469\u200D\U0001F4BB",                 // woman technologist
+	"\U0001F3F3\uFE0F\u200D\U0001F308",           // rainbow flag
+	"\U0001F9D1\u200D\U0001F680",                 // astronaut
+}
+
+// GraphemeString generates a random valid UTF-8 string of exactly graphemes user-perceived characters,
+// extended grapheme clusters as defined by Unicode UAX #29, default length is 6 if graphemes <= 0.
+// Each cluster is one of: an ASCII letter, a CJK ideograph, a Latin letter with one to three combining
+// diacritical marks, an emoji with or without a skin tone modifier, a ZWJ emoji sequence or a flag made
+// of two regional indicators. The string therefore has more runes and bytes than graphemes,
+// which trips up code that counts either as characters.
+// example: GraphemeString(4) returns "á̂👍🏽🇹🇭z"
+func GraphemeString(graphemes int) string {
+	if graphemes <= 0 {
+		graphemes = 6
+	}
+
+	var b strings.Builder
+	for i := 0; i < graphemes; i++ {
+		switch randIntn(6) {
+		case 0:
+			b.WriteByte(lowerChars[randIntn(len(lowerChars))])
+		case 1:
+			b.WriteRune(rune(0x4E00 + randIntn(0x9FFF-0x4E00+1)))
+		case 2:
+			b.WriteByte(lowerChars[randIntn(len(lowerChars))])
+			for n := 1 + randIntn(3); n > 0; n-- {
+				b.WriteRune(rune(0x0300 + randIntn(0x036F-0x0300+1)))
+			}
+		case 3:
+			if randIntn(2) == 0 {
+				b.WriteRune(rune(0x1F600 + randIntn(0x1F64F-0x1F600+1)))
+				break
+			}
+			b.WriteRune(graphemeModifierBases[randIntn(len(graphemeModifierBases))])
+			b.WriteRune(rune(0x1F3FB + randIntn(5)))
+		case 4:
+			b.WriteString(graphemeZWJSequences[randIntn(len(graphemeZWJSequences))])
+		default:
+			b.WriteRune(rune(0x1F1E6 + randIntn(26)))
+			b.WriteRune(rune(0x1F1E6 + randIntn(26)))
+		}
+	}
+
+	return b.String()
+}
+
+// WriteString appends size random characters of kind to b without building an intermediate string,
+// default length is 6 if size <= 0. It grows b by size up front.
+// example: b.WriteString("user_"); WriteString(&b, R_LOWER|R_NUM, 10)
+func WriteString(b *strings.Builder, kind, size int) {
+	if size <= 0 {
+		size = 6
+	}
+
+	chars := kindChars(kind)
+	b.Grow(size)
+	for i := 0; i < size; i++ {
+		b.WriteByte(chars[randIntn(len(chars))])
+	}
+}
+
+// EnglishFrequencies holds the relative frequencies of the letters a-z in English text,
+// in occurrences per 100000 letters. It is the default table of FrequencyString.
+var EnglishFrequencies = map[rune]int{
+	'a': 8167, 'b': 1492, 'c': 2782, 'd': 4253, 'e': 12702, 'f': 2228, 'g': 2015,
+	'h': 6094, 'i': 6966, 'j': 153, 'k': 772, 'l': 4025, 'm': 2406, 'n': 6749,
+	'o': 7507, 'p': 1929, 'q': 95, 'r': 5987, 's': 6327, 't': 9056, 'u': 2758,
+	'v': 978, 'w': 2360, 'x': 150, 'y': 1974, 'z': 74,
+}
+
+// FrequencyString generates a random string of size runes, each drawn with probability proportional
+// to its observed count in freq, default length is 6 if size <= 0. A nil or empty freq uses
+// EnglishFrequencies, runes with a count <= 0 are never drawn, at least one count must be positive.
+// example: FrequencyString(nil, 16), FrequencyString(map[rune]int{'a': 3, 'b': 1}, 16)
+func FrequencyString(freq map[rune]int, size int) (string, error) {
+	if len(freq) == 0 {
+		freq = EnglishFrequencies
+	}
+
+	runes := make([]rune, 0, len(freq))
+	for r, n := range freq {
+		if n > 0 {
+			runes = append(runes, r)
+		}
+	}
+	if len(runes) == 0 {
+		return "", errors.New("buuid: freq must have a positive count")
+	}
+	slices.Sort(runes)
+
+	weights := make([]int, len(runes))
+	for i, r := range runes {
+		weights[i] = freq[r]
+	}
+
+	a, err := NewWeightedAlphabet(runes, weights)
+	if err != nil {
+		return "", err
+	}
+
+	return a.String(size), nil
+}
@@ -0,0 +1,197 @@
+package buuid
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringNoRepeat(t *testing.T) {
+	assert.Equal(t, 6, len(StringNoRepeat(R_NUM, 0)))
+
+	for _, kind := range []int{R_NUM, R_UPPER, R_LOWER, R_NUM | R_LOWER, R_All} {
+		chars := string(kindChars(kind))
+		for i := 0; i < 100; i++ {
+			s := StringNoRepeat(kind, 64)
+			assert.Equal(t, 64, len(s))
+			for j := 0; j < len(s); j++ {
+				assert.True(t, strings.IndexByte(chars, s[j]) >= 0)
+				if j > 0 {
+					assert.NotEqual(t, s[j-1], s[j])
+				}
+			}
+		}
+	}
+}
+
+func TestWeightedAlphabet(t *testing.T) {
+	runes := []rune("aéz")
+	weights := []int{1, 3, 6}
+	a, err := NewWeightedAlphabet(runes, weights)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 6, len([]rune(a.String(0))))
+
+	l := 100000
+	counts := map[rune]int{}
+	for _, r := range a.String(l) {
+		counts[r]++
+	}
+	assert.Equal(t, len(runes), len(counts))
+	for i, r := range runes {
+		assert.InDelta(t, float64(weights[i])/10, float64(counts[r])/float64(l), 0.01)
+	}
+
+	_, err = NewWeightedAlphabet([]rune("ab"), []int{1})
+	assert.Error(t, err)
+	_, err = NewWeightedAlphabet([]rune("ab"), []int{1, 0})
+	assert.Error(t, err)
+	_, err = NewWeightedAlphabet(nil, nil)
+	assert.Error(t, err)
+}
+
+func TestFrequencyString(t *testing.T) {
+	freq := map[rune]int{'a': 1, 'b': 3, 'c': 6, 'd': 0, 'e': -2}
+	l := 100000
+	s, err := FrequencyString(freq, l)
+	assert.NoError(t, err)
+
+	counts := map[rune]int{}
+	for _, r := range s {
+		counts[r]++
+	}
+	assert.Equal(t, 3, len(counts))
+	for _, r := range "abc" {
+		assert.InDelta(t, float64(freq[r])/10, float64(counts[r])/float64(l), 0.01)
+	}
+
+	// the default English table draws only lowercase letters, e more often than z
+	s, err = FrequencyString(nil, l)
+	assert.NoError(t, err)
+	counts = map[rune]int{}
+	for _, r := range s {
+		assert.True(t, r >= 'a' && r <= 'z')
+		counts[r]++
+	}
+	assert.Greater(t, counts['e'], counts['z']*10)
+	assert.InDelta(t, 0.127, float64(counts['e'])/float64(l), 0.01)
+
+	s, err = FrequencyString(nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, len(s))
+
+	_, err = FrequencyString(map[rune]int{'a': 0}, 6)
+	assert.Error(t, err)
+}
+
+func TestGraphemeString(t *testing.T) {
+	s := GraphemeString(0)
+	assert.Equal(t, 6, uniseg.GraphemeClusterCount(s))
+
+	for _, n := range []int{1, 5, 50} {
+		for i := 0; i < 100; i++ {
+			s := GraphemeString(n)
+			assert.True(t, utf8.ValidString(s))
+			assert.Equal(t, n, uniseg.GraphemeClusterCount(s), "%q", s)
+			assert.True(t, utf8.RuneCountInString(s) >= n)
+		}
+	}
+
+	// a long string has more runes than graphemes
+	s = GraphemeString(200)
+	assert.Greater(t, utf8.RuneCountInString(s), 200)
+}
+
+func TestUnicodeString(t *testing.T) {
+	assert.Equal(t, 6, utf8.RuneCountInString(UnicodeString(0)))
+
+	for i := 0; i < 100; i++ {
+		s := UnicodeString(32)
+		assert.True(t, utf8.ValidString(s))
+		assert.Equal(t, 32, utf8.RuneCountInString(s))
+	}
+
+	// a range spanning the surrogates never yields one
+	for i := 0; i < 100; i++ {
+		s := UnicodeString(64, [2]rune{0xD7FE, 0xE001})
+		assert.True(t, utf8.ValidString(s))
+		assert.Equal(t, 64, utf8.RuneCountInString(s))
+		for _, r := range s {
+			assert.False(t, r >= 0xD800 && r <= 0xDFFF)
+			assert.True(t, (r >= 0xD7FE && r <= 0xD7FF) || (r >= 0xE000 && r <= 0xE001))
+		}
+	}
+
+	// reversed ranges and ranges beyond unicode.MaxRune
+	for _, r := range UnicodeString(64, [2]rune{'z', 'a'}, [2]rune{unicode.MaxRune, unicode.MaxRune + 10}) {
+		assert.True(t, (r >= 'a' && r <= 'z') || r == unicode.MaxRune)
+	}
+
+	assert.Equal(t, "", UnicodeString(10, [2]rune{0xD800, 0xDFFF}))
+}
+
+func TestWriteString(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("user_")
+	WriteString(&b, R_LOWER|R_NUM, 10)
+	b.WriteString("_end")
+
+	s := b.String()
+	assert.Equal(t, 19, len(s))
+	assert.True(t, strings.HasPrefix(s, "user_"))
+	assert.True(t, strings.HasSuffix(s, "_end"))
+	for _, c := range s[5:15] {
+		assert.True(t, (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9'))
+	}
+
+	b.Reset()
+	WriteString(&b, R_UPPER, 0)
+	assert.Equal(t, 6, b.Len())
+	for _, c := range b.String() {
+		assert.True(t, c >= 'A' && c <= 'Z')
+	}
+}
+
+func BenchmarkStringNoRepeat_16(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		StringNoRepeat(R_All, 16)
+	}
+}
+
+func BenchmarkFrequencyString_16(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = FrequencyString(nil, 16)
+	}
+}
+
+func BenchmarkGraphemeString_16(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		GraphemeString(16)
+	}
+}
+
+func BenchmarkWeightedAlphabet_String_16(b *testing.B) {
+	a, _ := NewWeightedAlphabet([]rune("abcdef"), []int{1, 2, 3, 4, 5, 6})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.String(16)
+	}
+}
+
+func BenchmarkUnicodeString_16(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		UnicodeString(16)
+	}
+}
+
+func BenchmarkWriteString_16(b *testing.B) {
+	var sb strings.Builder
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		WriteString(&sb, R_All, 16)
+	}
+}
@@ -0,0 +1,133 @@
+package buuid
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+// RandomTime generates a random time in the range of [start, end] with nanosecond precision,
+// start and end are swapped if start is after end, the result uses the location of start.
+func RandomTime(start, end time.Time) time.Time {
+	if start.After(end) {
+		start, end = end, start
+	}
+
+	// The offset of end from start is secs seconds plus nsec nanoseconds, 0 <= nsec < 1e9,
+	// secs is computed in uint64 as the difference of the Unix times can overflow int64.
+	const nsPerSec = uint64(time.Second)
+	secs := uint64(end.Unix()) - uint64(start.Unix())
+	nsec := int64(end.Nanosecond() - start.Nanosecond())
+	if nsec < 0 {
+		secs--
+		nsec += int64(time.Second)
+	}
+
+	var sec, ns uint64
+	if secs <= (math.MaxUint64-uint64(nsec))/nsPerSec {
+		// The span in nanoseconds fits in uint64, last+1 is 0 when it covers every uint64.
+		last := secs*nsPerSec + uint64(nsec)
+		var n uint64
+		if last == math.MaxUint64 {
+			var b [8]byte
+			readRandom(b[:])
+			n = binary.BigEndian.Uint64(b[:])
+		} else {
+			n = defaultGenerator.uint64n(last + 1)
+		}
+		sec, ns = n/nsPerSec, n%nsPerSec
+	} else {
+		// The range is longer than about 584 years, draw the seconds and the nanoseconds separately
+		// and reject the offsets past end, which happens with a probability below 1e-10.
+		for {
+			sec = defaultGenerator.uint64n(secs + 1)
+			ns = defaultGenerator.uint64n(nsPerSec)
+			if sec < secs || ns <= uint64(nsec) {
+				break
+			}
+		}
+	}
+
+	return time.Unix(start.Unix()+int64(sec), int64(start.Nanosecond())+int64(ns)).In(start.Location())
+}
+
+// RandomTimeInZone generates a random time in the range of [start, end] like RandomTime, expressed in loc,
+// a nil loc means UTC. The instant is drawn first and then converted, so the wall clock is always valid
+// in loc: local times skipped by a DST transition never occur and instants in a repeated hour keep
+// their own offset.
+// example: RandomTimeInZone(tokyo, start, end)
+func RandomTimeInZone(loc *time.Location, start, end time.Time) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return RandomTime(start, end).In(loc)
+}
+
+// RandomTimestamp generates a random time in the range of [start, end] formatted with layout.
+// example: RandomTimestamp(start, end, time.RFC3339)
+func RandomTimestamp(start, end time.Time, layout string) string {
+	return RandomTime(start, end).Format(layout)
+}
+
+// RandomUnixTimestamp generates a random time in the range of [start, end] as Unix seconds.
+func RandomUnixTimestamp(start, end time.Time) int64 {
+	return RandomTime(start, end).Unix()
+}
+
+// RecentTime generates a random time in the range of [now-maxAge, now] biased toward now,
+// the age follows an exponential distribution with a half-life of maxAge/4 truncated at maxAge,
+// so about half of the results are younger than maxAge/4.
+func RecentTime(maxAge time.Duration) time.Time {
+	return RecentTimeWithHalfLife(maxAge, maxAge/4)
+}
+
+// RecentTimeWithHalfLife is like RecentTime, but with the half-life of the age distribution set by halfLife,
+// the result is uniform in [now-maxAge, now] if halfLife <= 0.
+func RecentTimeWithHalfLife(maxAge, halfLife time.Duration) time.Time {
+	now := time.Now()
+	if maxAge <= 0 {
+		return now
+	}
+	if halfLife <= 0 {
+		return RandomTime(now.Add(-maxAge), now)
+	}
+
+	// Inverse CDF of the exponential distribution truncated to [0, maxAge].
+	lambda := math.Ln2 / float64(halfLife)
+	u := randFloat()
+	age := -math.Log(1-u*(1-math.Exp(-lambda*float64(maxAge)))) / lambda
+	if age > float64(maxAge) {
+		age = float64(maxAge)
+	}
+
+	return now.Add(-time.Duration(age))
+}
+
+// BackoffSchedule returns attempts retry delays with exponential backoff and full jitter,
+// delay i is uniform in [0, min(base*2^i, max)], an empty schedule is returned if attempts <= 0.
+// example: BackoffSchedule(5, 100*time.Millisecond, 2*time.Second)
+func BackoffSchedule(attempts int, base, max time.Duration) []time.Duration {
+	if attempts <= 0 {
+		return []time.Duration{}
+	}
+
+	schedule := make([]time.Duration, attempts)
+	ceiling := base
+	for i := range schedule {
+		if ceiling > max {
+			ceiling = max
+		}
+		if ceiling > 0 {
+			schedule[i] = time.Duration(defaultGenerator.uint64n(uint64(ceiling) + 1))
+		}
+
+		// double without overflowing
+		if ceiling > math.MaxInt64/2 {
+			ceiling = math.MaxInt64
+		} else {
+			ceiling *= 2
+		}
+	}
+
+	return schedule
+}
@@ -0,0 +1,183 @@
+package buuid
+
+import (
+	"math"
+	"sort"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomTime(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 500, time.UTC)
+	end := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 100; i++ {
+		tm := RandomTime(start, end)
+		assert.False(t, tm.Before(start))
+		assert.False(t, tm.After(end))
+
+		// [max, min]
+		tm = RandomTime(end, start)
+		assert.False(t, tm.Before(start))
+		assert.False(t, tm.After(end))
+	}
+
+	// span wider than time.Duration can hold
+	start = time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC)
+	end = time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 100; i++ {
+		tm := RandomTime(start, end)
+		assert.False(t, tm.Before(start))
+		assert.False(t, tm.After(end))
+	}
+
+	assert.True(t, start.Equal(RandomTime(start, start)))
+
+	// nanoseconds of end below those of start
+	start = time.Date(2020, 1, 1, 0, 0, 0, 999999999, time.UTC)
+	end = time.Date(2020, 1, 1, 0, 0, 1, 0, time.UTC)
+	for i := 0; i < 100; i++ {
+		tm := RandomTime(start, end)
+		assert.True(t, tm.Equal(start) || tm.Equal(end))
+	}
+
+	// the offset is drawn from the default generator
+	saved := defaultGenerator
+	defer func() { defaultGenerator = saved }()
+	defaultGenerator = NewFastGenerator(1)
+	a := RandomTime(start, start.Add(time.Hour))
+	defaultGenerator = NewFastGenerator(1)
+	assert.True(t, a.Equal(RandomTime(start, start.Add(time.Hour))))
+}
+
+func TestRandomTimeInZone(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	// 2024-03-10 02:00 EST jumps to 03:00 EDT, 2024-11-03 02:00 EDT falls back to 01:00 EST
+	for _, day := range []time.Time{
+		time.Date(2024, 3, 10, 6, 0, 0, 0, time.UTC),
+		time.Date(2024, 11, 3, 5, 0, 0, 0, time.UTC),
+	} {
+		start, end := day.Add(-2*time.Hour), day.Add(2*time.Hour)
+		for i := 0; i < 1000; i++ {
+			tm := RandomTimeInZone(ny, start, end)
+			assert.Equal(t, ny, tm.Location())
+			assert.False(t, tm.Before(start) || tm.After(end))
+
+			// the wall clock is a valid local time that maps back to the same instant
+			if day.Month() == time.March {
+				assert.False(t, tm.Hour() == 2, "skipped local time %v", tm)
+			}
+			_, offset := tm.Zone()
+			back := time.Date(tm.Year(), tm.Month(), tm.Day(), tm.Hour(), tm.Minute(), tm.Second(), tm.Nanosecond(),
+				time.FixedZone("", offset))
+			assert.True(t, back.Equal(tm))
+		}
+	}
+
+	now := time.Now()
+	tm := RandomTimeInZone(nil, now, now.Add(time.Hour))
+	assert.Equal(t, time.UTC, tm.Location())
+}
+
+func TestRandomTimestamp(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 100; i++ {
+		s := RandomTimestamp(start, end, time.RFC3339)
+		tm, err := time.Parse(time.RFC3339, s)
+		assert.NoError(t, err)
+		assert.False(t, tm.Before(start))
+		assert.False(t, tm.After(end))
+	}
+}
+
+func TestRandomUnixTimestamp(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 100; i++ {
+		n := RandomUnixTimestamp(start, end)
+		assert.True(t, n >= start.Unix() && n <= end.Unix())
+	}
+}
+
+func TestRecentTime(t *testing.T) {
+	maxAge := time.Hour
+	l := 2000
+	ages := make([]time.Duration, 0, l)
+	for i := 0; i < l; i++ {
+		before := time.Now()
+		tm := RecentTime(maxAge)
+		after := time.Now()
+		assert.False(t, tm.After(after))
+		assert.False(t, tm.Before(before.Add(-maxAge)))
+		ages = append(ages, after.Sub(tm))
+	}
+
+	// the median age is close to the half-life, far below the uniform median of maxAge/2
+	sort.Slice(ages, func(i, j int) bool { return ages[i] < ages[j] })
+	median := ages[l/2]
+	assert.True(t, median < maxAge/3, median)
+	assert.True(t, median > maxAge/8, median)
+}
+
+func TestRecentTimeWithHalfLife(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		before := time.Now()
+		tm := RecentTimeWithHalfLife(time.Minute, 0)
+		assert.False(t, tm.After(time.Now()))
+		assert.False(t, tm.Before(before.Add(-time.Minute)))
+	}
+
+	now := time.Now()
+	assert.False(t, RecentTimeWithHalfLife(0, time.Second).Before(now))
+}
+
+func TestBackoffSchedule(t *testing.T) {
+	assert.Equal(t, 0, len(BackoffSchedule(0, time.Second, time.Minute)))
+
+	base, max := 100*time.Millisecond, 2*time.Second
+	for i := 0; i < 100; i++ {
+		s := BackoffSchedule(10, base, max)
+		assert.Equal(t, 10, len(s))
+		for j, d := range s {
+			ceiling := base << j
+			if ceiling > max {
+				ceiling = max
+			}
+			assert.True(t, d >= 0 && d <= ceiling, d)
+		}
+	}
+
+	// doubling saturates instead of overflowing
+	for _, d := range BackoffSchedule(100, time.Hour, math.MaxInt64) {
+		assert.True(t, d >= 0)
+	}
+
+	for _, d := range BackoffSchedule(5, 0, time.Second) {
+		assert.Equal(t, time.Duration(0), d)
+	}
+}
+
+func BenchmarkRandomTimeInZone(b *testing.B) {
+	loc := time.FixedZone("UTC+7", 7*60*60)
+	start := time.Now()
+	end := start.AddDate(1, 0, 0)
+	for i := 0; i < b.N; i++ {
+		RandomTimeInZone(loc, start, end)
+	}
+}
+
+func BenchmarkRandomTime(b *testing.B) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < b.N; i++ {
+		RandomTime(start, end)
+	}
+}
@@ -0,0 +1,187 @@
+package buuid
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ConstantTimeEqual reports whether a and b are equal in time that does not depend on their content,
+// use it instead of == to compare secrets such as generated tokens.
+// Strings of different lengths are unequal, only the length may leak through timing.
+func ConstantTimeEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// TokenWithHash generates a random token of byteLen bytes, base64url encoded without padding,
+// and the hex SHA-256 digest of the token string, default length is 32 bytes if byteLen <= 0.
+// Hand the token to the client once and store only the hash, a presented token is checked by
+// hashing it and comparing with ConstantTimeEqual.
+func TokenWithHash(byteLen int) (token string, hashHex string) {
+	if byteLen <= 0 {
+		byteLen = 32
+	}
+
+	b := make([]byte, byteLen)
+	readRandom(b)
+	token = base64.RawURLEncoding.EncodeToString(b)
+	sum := sha256.Sum256([]byte(token))
+
+	return token, hex.EncodeToString(sum[:])
+}
+
+// Base64 generates a padded base64 string, standard or, if urlSafe is true, URL-safe (RFC 4648 section 5),
+// that decodes to exactly decodedLen random bytes, an empty string is returned if decodedLen is not positive.
+// example: Base64(16, true) returns something like "q3HBx_0p9TQ-Lk2mWz7A1g=="
+func Base64(decodedLen int, urlSafe bool) string {
+	if decodedLen <= 0 {
+		return ""
+	}
+
+	b := make([]byte, decodedLen)
+	readRandom(b)
+	if urlSafe {
+		return base64.URLEncoding.EncodeToString(b)
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// chunkAlphabet is the RFC 4648 base32 alphabet ChunkedToken encodes with.
+const chunkAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+
+// luhnMod32 returns the Luhn mod N sum, N=32, of the chunkAlphabet characters of s, or -1 if s has
+// another character. checked tells whether the last character of s is a check character.
+func luhnMod32(s string, checked bool) int {
+	factor, sum := 2, 0
+	if checked {
+		factor = 1
+	}
+	for i := len(s) - 1; i >= 0; i-- {
+		code := strings.IndexByte(chunkAlphabet, s[i])
+		if code < 0 {
+			return -1
+		}
+		addend := factor * code
+		sum += addend/32 + addend%32
+		factor = 3 - factor
+	}
+	return sum % 32
+}
+
+// ChunkedToken generates a random token of byteLen bytes, base32 encoded without padding and split into
+// chunks of chunkSize characters joined by hyphens, the last chunk may be shorter. Every chunk ends with
+// a Luhn mod 32 check character, so a mistyped or swapped character is detected per chunk,
+// see ValidChunkedToken. byteLen and chunkSize must be positive.
+// example: ChunkedToken(10, 4) returns KQ3NW-BE2XH-O7RCQ-YSA4M
+func ChunkedToken(byteLen, chunkSize int) (string, error) {
+	if byteLen <= 0 {
+		return "", errors.New("buuid: byteLen must be positive")
+	}
+	if chunkSize <= 0 {
+		return "", errors.New("buuid: chunkSize must be positive")
+	}
+
+	b := make([]byte, byteLen)
+	readRandom(b)
+	data := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b)
+
+	var sb strings.Builder
+	for i := 0; i < len(data); i += chunkSize {
+		if i > 0 {
+			sb.WriteByte('-')
+		}
+		chunk := data[i:min(i+chunkSize, len(data))]
+		sb.WriteString(chunk)
+		sb.WriteByte(chunkAlphabet[(32-luhnMod32(chunk, false))%32])
+	}
+
+	return sb.String(), nil
+}
+
+// ValidChunkedToken reports whether every hyphen-separated chunk of s is uppercase base32 data
+// followed by its correct check character, as generated by ChunkedToken.
+func ValidChunkedToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, chunk := range strings.Split(s, "-") {
+		if len(chunk) < 2 || luhnMod32(chunk, true) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// fakeJWTHeader is the base64url encoded {"alg":"HS256","typ":"JWT"} header of FakeJWT.
+var fakeJWTHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// FakeJWT generates a structurally valid JWT, header.payload.signature, for test stubs only:
+// the header and the claims are base64url encoded JSON but the signature is 32 random bytes,
+// so the token never passes real signature verification.
+func FakeJWT(claims map[string]any) (string, error) {
+	if claims == nil {
+		claims = map[string]any{}
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	sig := make([]byte, 32)
+	readRandom(sig)
+
+	return fakeJWTHeader + "." + base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// maxSafeCodeRetries bounds how many codes SafeCode generates before giving up.
+const maxSafeCodeRetries = 1000
+
+// DefaultBlocklist holds the lowercase offensive and reserved terms SafeCode rejects by default,
+// replace or extend it to change the default for the whole program.
+var DefaultBlocklist = []string{
+	"anal", "anus", "arse", "ass", "bitch", "boob", "butt", "cock", "crap", "cum", "cunt", "damn",
+	"dick", "dildo", "fag", "fuck", "jizz", "kkk", "nazi", "nigg", "penis", "piss", "poo", "porn",
+	"pussy", "rape", "sex", "shit", "slut", "tit", "twat", "wank", "whore",
+	"admin", "null", "root", "system", "test",
+}
+
+// SafeCode generates a random uppercase alphanumeric code of length characters that contains
+// no term of the blocklist as a case-insensitive substring, default length is 6 if length <= 0.
+// The blocklist defaults to DefaultBlocklist if none is given.
+// An error is returned if no acceptable code is found within maxSafeCodeRetries attempts.
+// example: SafeCode(8), SafeCode(8, "foo", "bar")
+func SafeCode(length int, blocklist ...string) (string, error) {
+	if len(blocklist) == 0 {
+		blocklist = DefaultBlocklist
+	}
+	terms := make([]string, 0, len(blocklist))
+	for _, term := range blocklist {
+		if term != "" {
+			terms = append(terms, strings.ToLower(term))
+		}
+	}
+
+	for i := 0; i < maxSafeCodeRetries; i++ {
+		code := String(R_NUM|R_UPPER, length)
+		if !containsAny(strings.ToLower(code), terms) {
+			return code, nil
+		}
+	}
+
+	return "", errors.New("buuid: no code without a blocklisted term was found")
+}
+
+func containsAny(s string, terms []string) bool {
+	for _, term := range terms {
+		if strings.Contains(s, term) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+package buuid
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstantTimeEqual(t *testing.T) {
+	s := String(R_All, 32)
+	assert.True(t, ConstantTimeEqual(s, s))
+	assert.True(t, ConstantTimeEqual("", ""))
+	assert.False(t, ConstantTimeEqual(s, s[:31]+"!"))
+	assert.False(t, ConstantTimeEqual(s, s[:16]))
+	assert.False(t, ConstantTimeEqual("", s))
+}
+
+func TestTokenWithHash(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		token, hash := TokenWithHash(24)
+		assert.Equal(t, 32, len(token))
+		sum := sha256.Sum256([]byte(token))
+		assert.Equal(t, hex.EncodeToString(sum[:]), hash)
+		assert.False(t, seen[token])
+		seen[token] = true
+	}
+
+	token, hash := TokenWithHash(0)
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	assert.NoError(t, err)
+	assert.Equal(t, 32, len(b))
+	assert.Equal(t, 64, len(hash))
+}
+
+func TestBase64(t *testing.T) {
+	assert.Equal(t, "", Base64(0, false))
+
+	for n := 1; n <= 40; n++ {
+		for _, urlSafe := range []bool{false, true} {
+			enc := base64.StdEncoding
+			if urlSafe {
+				enc = base64.URLEncoding
+			}
+			for i := 0; i < 10; i++ {
+				s := Base64(n, urlSafe)
+				assert.Equal(t, (n+2)/3*4, len(s))
+				b, err := enc.DecodeString(s)
+				assert.NoError(t, err)
+				assert.Equal(t, n, len(b))
+				if urlSafe {
+					assert.False(t, strings.ContainsAny(s, "+/"))
+				} else {
+					assert.False(t, strings.ContainsAny(s, "-_"))
+				}
+			}
+		}
+	}
+}
+
+func TestChunkedToken(t *testing.T) {
+	re := regexp.MustCompile(`^[A-Z2-7]{5}(-[A-Z2-7]{5})*(-[A-Z2-7]{2,5})?$`)
+	for i := 0; i < 100; i++ {
+		s, err := ChunkedToken(20, 4)
+		assert.NoError(t, err)
+		assert.Regexp(t, re, s)
+		// 20 bytes are 32 base32 characters, 8 chunks of 4 plus a check character
+		assert.Equal(t, 8, len(strings.Split(s, "-")))
+		assert.True(t, ValidChunkedToken(s), s)
+
+		// corrupting any single character is detected
+		b := []byte(s)
+		pos := Int(0, len(b)-1)
+		for b[pos] == '-' {
+			pos = Int(0, len(b)-1)
+		}
+		c := strings.IndexByte(chunkAlphabet, b[pos])
+		b[pos] = chunkAlphabet[(c+Int(1, 31))%32]
+		assert.False(t, ValidChunkedToken(string(b)), string(b))
+
+		// so is swapping two adjacent distinct characters of a chunk,
+		// except A and 7, the Luhn blind spot like 0 and 9 in mod 10
+		b = []byte(s)
+		if b[0] != b[1] && !strings.Contains("A7 7A", string(b[:2])) {
+			b[0], b[1] = b[1], b[0]
+			assert.False(t, ValidChunkedToken(string(b)), string(b))
+		}
+	}
+
+	// the last chunk may be shorter
+	s, err := ChunkedToken(5, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 4, 3}, []int{len(s[:4]), len(s[5:9]), len(s[10:])})
+	assert.True(t, ValidChunkedToken(s))
+
+	assert.False(t, ValidChunkedToken(""))
+	assert.False(t, ValidChunkedToken("A"))
+	assert.False(t, ValidChunkedToken(strings.ToLower(s)))
+	assert.False(t, ValidChunkedToken(s+"-"))
+
+	_, err = ChunkedToken(0, 4)
+	assert.Error(t, err)
+	_, err = ChunkedToken(16, 0)
+	assert.Error(t, err)
+}
+
+func TestFakeJWT(t *testing.T) {
+	claims := map[string]any{"sub": "1234567890", "admin": true, "iat": 1516239022.0}
+	s, err := FakeJWT(claims)
+	assert.NoError(t, err)
+
+	parts := strings.Split(s, ".")
+	assert.Equal(t, 3, len(parts))
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"alg":"HS256","typ":"JWT"}`, string(header))
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	assert.NoError(t, err)
+	var decoded map[string]any
+	assert.NoError(t, json.Unmarshal(payload, &decoded))
+	assert.Equal(t, claims, decoded)
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	assert.NoError(t, err)
+	assert.Equal(t, 32, len(sig))
+
+	s, err = FakeJWT(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(strings.Split(s, ".")))
+
+	_, err = FakeJWT(map[string]any{"bad": make(chan int)})
+	assert.Error(t, err)
+}
+
+func TestSafeCode(t *testing.T) {
+	s, err := SafeCode(0)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, len(s))
+
+	// a small blocklist of terms that would otherwise be common
+	blocklist := []string{"a", "B", "1", "z9"}
+	for i := 0; i < 1000; i++ {
+		s, err := SafeCode(8, blocklist...)
+		assert.NoError(t, err)
+		assert.Equal(t, 8, len(s))
+		assert.False(t, containsAny(strings.ToLower(s), []string{"a", "b", "1", "z9"}), s)
+	}
+
+	for i := 0; i < 100; i++ {
+		s, err := SafeCode(12)
+		assert.NoError(t, err)
+		assert.False(t, containsAny(strings.ToLower(s), DefaultBlocklist), s)
+	}
+
+	_, err = SafeCode(8, strings.Split("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ", "")...)
+	assert.Error(t, err)
+}
+
+func BenchmarkTokenWithHash(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		TokenWithHash(32)
+	}
+}
+
+func BenchmarkBase64_32(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Base64(32, true)
+	}
+}
+
+func BenchmarkChunkedToken(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = ChunkedToken(20, 4)
+	}
+}
+
+func BenchmarkConstantTimeEqual(b *testing.B) {
+	s1, s2 := String(R_All, 32), String(R_All, 32)
+	for i := 0; i < b.N; i++ {
+		ConstantTimeEqual(s1, s2)
+	}
+}
@@ -0,0 +1,259 @@
+package buuid
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidUUID is returned when a string is not a valid UUID.
+var ErrInvalidUUID = errors.New("buuid: invalid UUID")
+
+// newUUIDv4 generates the raw 16 bytes of a random (version 4, RFC 4122 variant) UUID.
+func newUUIDv4() [16]byte {
+	var u [16]byte
+	readRandom(u[:])
+	u[6] = (u[6] & 0x0f) | 0x40 // version 4
+	u[8] = (u[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return u
+}
+
+// formatUUID formats u in the canonical 8-4-4-4-12 lowercase form.
+func formatUUID(u [16]byte) string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:36], u[10:16])
+	return string(buf[:])
+}
+
+// Variant is the layout family of a UUID, encoded in the high bits of byte 8, the first byte of the
+// fourth group, where x marks random bits.
+type Variant int
+
+const (
+	// VariantRFC4122 is the RFC 4122 and RFC 9562 layout, bits 10xx, the fourth group starts with 8-b.
+	// It is the zero value.
+	VariantRFC4122 Variant = iota
+	// VariantNCS is the NCS backward compatible layout, bits 0xxx, the fourth group starts with 0-7.
+	VariantNCS
+	// VariantMicrosoft is the Microsoft backward compatible GUID layout, bits 110x, the fourth group starts with c-d.
+	VariantMicrosoft
+)
+
+// UUIDv4WithVariant generates a random UUID with the version 4 bits and the given variant bits,
+// for interop with legacy systems that expect a non-RFC variant, most users want UUIDv4.
+// An unknown variant is treated as VariantRFC4122.
+// example: UUIDv4WithVariant(VariantMicrosoft) returns 9b2f7c1e-4d3a-4f6b-ce21-0c5d7a9b3e42
+func UUIDv4WithVariant(variant Variant) string {
+	u := newUUIDv4()
+	switch variant {
+	case VariantNCS:
+		u[8] &= 0x7f
+	case VariantMicrosoft:
+		u[8] = (u[8] & 0x1f) | 0xc0
+	}
+	return formatUUID(u)
+}
+
+// UUIDv4 generates a random version 4 UUID in the canonical lowercase form.
+// example: 9b2f7c1e-4d3a-4f6b-8e21-0c5d7a9b3e42
+func UUIDv4() string {
+	return formatUUID(newUUIDv4())
+}
+
+// UUIDv4Upper generates a random version 4 UUID in uppercase.
+// example: 9B2F7C1E-4D3A-4F6B-8E21-0C5D7A9B3E42
+func UUIDv4Upper() string {
+	return strings.ToUpper(UUIDv4())
+}
+
+// GUID generates a random version 4 UUID in the uppercase, brace-wrapped Microsoft GUID form.
+// example: {9B2F7C1E-4D3A-4F6B-8E21-0C5D7A9B3E42}
+func GUID() string {
+	return "{" + UUIDv4Upper() + "}"
+}
+
+// UUIDv4Compact generates a random version 4 UUID as 32 lowercase hex characters without hyphens.
+// example: 9b2f7c1e4d3a4f6b8e210c5d7a9b3e42
+func UUIDv4Compact() string {
+	u := newUUIDv4()
+	return hex.EncodeToString(u[:])
+}
+
+// UUIDv4URN generates a random version 4 UUID as a URN, RFC 4122 section 3.
+// example: urn:uuid:9b2f7c1e-4d3a-4f6b-8e21-0c5d7a9b3e42
+func UUIDv4URN() string {
+	return "urn:uuid:" + UUIDv4()
+}
+
+// TaggedUUID generates a version 4 UUID whose first 16 bits hold tag, such as a tenant or shard number,
+// so the tag is the leading 4 hex characters and can be read back with UUIDTag.
+// The tag takes the place of random bits, leaving 106 random bits instead of 122,
+// UUIDs sharing a tag collide as often as random 106-bit values.
+// example: TaggedUUID(0x002a) returns 002a7c1e-4d3a-4f6b-8e21-0c5d7a9b3e42
+func TaggedUUID(tag uint16) string {
+	u := newUUIDv4()
+	u[0], u[1] = byte(tag>>8), byte(tag)
+	return formatUUID(u)
+}
+
+// UUIDTag returns the tag embedded by TaggedUUID in s, any form accepted by ParseUUID is allowed.
+func UUIDTag(s string) (uint16, error) {
+	u, err := ParseUUID(s)
+	if err != nil {
+		return 0, err
+	}
+	return uint16(u[0])<<8 | uint16(u[1]), nil
+}
+
+// gregorianOffset is the number of 100-nanosecond intervals from the Gregorian epoch,
+// 1582-10-15 00:00:00 UTC, to the Unix epoch.
+const gregorianOffset = 0x01B21DD213814000
+
+// uuidV1State holds the clock sequence and node of UUIDv1, both drawn randomly on first use.
+var uuidV1State struct {
+	mu       sync.Mutex
+	once     sync.Once
+	lastTS   int64
+	clockSeq uint16
+	node     [6]byte
+}
+
+// UUIDv1 generates a time-based version 1 UUID, RFC 4122: a 60-bit count of 100-nanosecond intervals since
+// the Gregorian epoch, a 14-bit clock sequence and a 48-bit node. The node is random per process with
+// the multicast bit set, as RFC 4122 requires for nodes that are not a real MAC address. The clock
+// sequence is incremented whenever the timestamp does not advance, so UUIDs generated within the same
+// 100-nanosecond tick or after the clock moved backwards stay distinct.
+// example: 5c4a7f1e-6b2d-11ef-9a3b-0b1c2d3e4f5a
+func UUIDv1() string {
+	st := &uuidV1State
+	st.once.Do(func() {
+		var b [8]byte
+		readRandom(b[:])
+		st.clockSeq = binary.BigEndian.Uint16(b[:2]) & 0x3fff
+		copy(st.node[:], b[2:])
+		st.node[0] |= 0x01 // multicast bit
+	})
+
+	st.mu.Lock()
+	ts := time.Now().UnixNano()/100 + gregorianOffset
+	if ts <= st.lastTS {
+		st.clockSeq = (st.clockSeq + 1) & 0x3fff
+	}
+	st.lastTS = ts
+	seq, node := st.clockSeq, st.node
+	st.mu.Unlock()
+
+	var u [16]byte
+	binary.BigEndian.PutUint32(u[0:4], uint32(ts))
+	binary.BigEndian.PutUint16(u[4:6], uint16(ts>>32))
+	binary.BigEndian.PutUint16(u[6:8], uint16(ts>>48)&0x0fff|0x1000) // version 1
+	binary.BigEndian.PutUint16(u[8:10], seq|0x8000)                  // RFC 4122 variant
+	copy(u[10:], node[:])
+	return formatUUID(u)
+}
+
+// UUIDv1Time returns the creation time encoded in a version 1 UUID, with 100-nanosecond precision,
+// any form accepted by ParseUUID is allowed.
+func UUIDv1Time(s string) (time.Time, error) {
+	u, err := ParseUUID(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if u[6]>>4 != 1 {
+		return time.Time{}, fmt.Errorf("%w: not a version 1 UUID", ErrInvalidUUID)
+	}
+
+	ts := int64(binary.BigEndian.Uint32(u[0:4])) |
+		int64(binary.BigEndian.Uint16(u[4:6]))<<32 |
+		int64(binary.BigEndian.Uint16(u[6:8])&0x0fff)<<48
+	// Split into seconds first, in nanoseconds the time overflows int64 after 2262.
+	ticks := ts - gregorianOffset
+	return time.Unix(ticks/1e7, ticks%1e7*100), nil
+}
+
+// UUIDv7 generates a time-ordered version 7 UUID, RFC 9562: a 48-bit big-endian Unix timestamp
+// in milliseconds followed by 74 random bits, so UUIDs of different milliseconds sort by time.
+// example: 0190a6b2-3c4d-7e5f-9a1b-2c3d4e5f6a7b
+func UUIDv7() string {
+	var u [16]byte
+	readRandom(u[6:])
+	ms := time.Now().UnixMilli()
+	for i := 5; i >= 0; i-- {
+		u[i] = byte(ms)
+		ms >>= 8
+	}
+	u[6] = (u[6] & 0x0f) | 0x70 // version 7
+	u[8] = (u[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return formatUUID(u)
+}
+
+// UUID generates a UUID of the given version in the canonical lowercase form,
+// the supported versions are 4 (random, see UUIDv4) and 7 (time-ordered, see UUIDv7).
+func UUID(version int) (string, error) {
+	switch version {
+	case 4:
+		return UUIDv4(), nil
+	case 7:
+		return UUIDv7(), nil
+	default:
+		return "", fmt.Errorf("buuid: unsupported UUID version %d", version)
+	}
+}
+
+// ParseUUID parses a UUID in the canonical form, case-insensitive and optionally wrapped in braces,
+// or in the compact form of 32 hex characters.
+func ParseUUID(s string) ([16]byte, error) {
+	var u [16]byte
+
+	if len(s) == 32 {
+		if _, err := hex.Decode(u[:], []byte(s)); err != nil {
+			return u, ErrInvalidUUID
+		}
+		return u, nil
+	}
+
+	if len(s) == 38 && s[0] == '{' && s[37] == '}' {
+		s = s[1:37]
+	}
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, ErrInvalidUUID
+	}
+
+	src := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36]
+	if _, err := hex.Decode(u[:], []byte(src)); err != nil {
+		return u, ErrInvalidUUID
+	}
+
+	return u, nil
+}
+
+// UUIDVersion returns the version of the UUID s, 1 to 8 as defined by RFC 9562, any form accepted by ParseUUID
+// is allowed. An error wrapping ErrInvalidUUID is returned if s is malformed, if its variant is not the RFC 4122
+// one, for which the version field has no meaning, or if the version is outside 1 to 8, such as the nil UUID.
+func UUIDVersion(s string) (int, error) {
+	u, err := ParseUUID(s)
+	if err != nil {
+		return 0, err
+	}
+	if u[8]&0xc0 != 0x80 {
+		return 0, fmt.Errorf("%w: not an RFC 4122 variant UUID", ErrInvalidUUID)
+	}
+
+	v := int(u[6] >> 4)
+	if v < 1 || v > 8 {
+		return 0, fmt.Errorf("%w: unknown version %d", ErrInvalidUUID, v)
+	}
+	return v, nil
+}
@@ -0,0 +1,296 @@
+package buuid
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUUIDv4(t *testing.T) {
+	re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	for i := 0; i < 10; i++ {
+		s := UUIDv4()
+		assert.Regexp(t, re, s)
+
+		u, err := ParseUUID(s)
+		assert.NoError(t, err)
+		assert.Equal(t, s, formatUUID(u))
+	}
+}
+
+func TestUUIDv4WithVariant(t *testing.T) {
+	cases := map[Variant]struct{ mask, bits byte }{
+		VariantNCS:       {0x80, 0x00},
+		VariantRFC4122:   {0xc0, 0x80},
+		VariantMicrosoft: {0xe0, 0xc0},
+		Variant(7):       {0xc0, 0x80}, // unknown is RFC 4122
+	}
+	for variant, c := range cases {
+		for i := 0; i < 100; i++ {
+			s := UUIDv4WithVariant(variant)
+			assert.Equal(t, byte('4'), s[14])
+
+			u, err := ParseUUID(s)
+			assert.NoError(t, err)
+			assert.Equal(t, c.bits, u[8]&c.mask, s)
+		}
+	}
+
+	var zero Variant
+	assert.Equal(t, VariantRFC4122, zero)
+
+	v, err := UUIDVersion(UUIDv4WithVariant(VariantRFC4122))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, v)
+}
+
+func TestUUIDv4Upper(t *testing.T) {
+	re := regexp.MustCompile(`^[0-9A-F]{8}-[0-9A-F]{4}-4[0-9A-F]{3}-[89AB][0-9A-F]{3}-[0-9A-F]{12}$`)
+	for i := 0; i < 10; i++ {
+		s := UUIDv4Upper()
+		assert.Regexp(t, re, s)
+
+		u, err := ParseUUID(s)
+		assert.NoError(t, err)
+		assert.Equal(t, strings.ToLower(s), formatUUID(u))
+	}
+}
+
+func TestGUID(t *testing.T) {
+	re := regexp.MustCompile(`^\{[0-9A-F]{8}-[0-9A-F]{4}-4[0-9A-F]{3}-[89AB][0-9A-F]{3}-[0-9A-F]{12}\}$`)
+	for i := 0; i < 10; i++ {
+		s := GUID()
+		assert.Regexp(t, re, s)
+
+		u, err := ParseUUID(s)
+		assert.NoError(t, err)
+		assert.Equal(t, strings.ToLower(s[1:37]), formatUUID(u))
+	}
+}
+
+func TestUUIDv4Compact(t *testing.T) {
+	re := regexp.MustCompile(`^[0-9a-f]{12}4[0-9a-f]{3}[89ab][0-9a-f]{15}$`)
+	for i := 0; i < 10; i++ {
+		s := UUIDv4Compact()
+		assert.Equal(t, 32, len(s))
+		assert.Regexp(t, re, s)
+
+		u, err := ParseUUID(s)
+		assert.NoError(t, err)
+		assert.Equal(t, s, strings.ReplaceAll(formatUUID(u), "-", ""))
+	}
+}
+
+func TestUUIDv4URN(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		s := UUIDv4URN()
+		assert.True(t, strings.HasPrefix(s, "urn:uuid:"))
+		assert.Equal(t, 45, len(s))
+
+		u, err := ParseUUID(strings.TrimPrefix(s, "urn:uuid:"))
+		assert.NoError(t, err)
+		assert.Equal(t, s[9:], formatUUID(u))
+	}
+}
+
+func TestTaggedUUID(t *testing.T) {
+	re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	seen := map[string]bool{}
+	for _, tag := range []uint16{0, 1, 0x2a, 0xbeef, 0xffff} {
+		for i := 0; i < 10; i++ {
+			s := TaggedUUID(tag)
+			assert.Regexp(t, re, s)
+			assert.False(t, seen[s])
+			seen[s] = true
+
+			got, err := UUIDTag(s)
+			assert.NoError(t, err)
+			assert.Equal(t, tag, got)
+
+			got, err = UUIDTag(strings.ToUpper(s))
+			assert.NoError(t, err)
+			assert.Equal(t, tag, got)
+		}
+	}
+
+	// the bits after the tag are still random
+	a, b := TaggedUUID(7), TaggedUUID(7)
+	assert.Equal(t, a[:4], b[:4])
+	assert.NotEqual(t, a[4:], b[4:])
+
+	_, err := UUIDTag("not-a-uuid")
+	assert.ErrorIs(t, err, ErrInvalidUUID)
+}
+
+func TestUUIDv1(t *testing.T) {
+	re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-1[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	before := time.Now().Truncate(100 * time.Nanosecond)
+	seen := map[string]bool{}
+	for i := 0; i < 10000; i++ {
+		s := UUIDv1()
+		assert.Regexp(t, re, s)
+		if seen[s] {
+			t.Fatalf("duplicate UUID %s", s)
+		}
+		seen[s] = true
+
+		// the node has the multicast bit set
+		node, _ := strconv.ParseUint(s[24:26], 16, 8)
+		assert.Equal(t, uint64(1), node&1)
+
+		tm, err := UUIDv1Time(s)
+		assert.NoError(t, err)
+		assert.False(t, tm.Before(before))
+		assert.False(t, tm.After(time.Now()))
+	}
+
+	// the version 1 test vector of RFC 9562 appendix A.1
+	tm, err := UUIDv1Time("c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC), tm.UTC())
+
+	// the largest 60-bit time, past the int64 nanosecond range
+	tm, err = UUIDv1Time("ffffffff-ffff-1fff-8000-000000000000")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(5236, 3, 31, 21, 21, 0, 684697500, time.UTC), tm.UTC())
+
+	// the Gregorian epoch, before the Unix epoch
+	tm, err = UUIDv1Time("00000000-0000-1000-8000-000000000000")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC), tm.UTC())
+
+	_, err = UUIDv1Time(UUIDv4())
+	assert.ErrorIs(t, err, ErrInvalidUUID)
+	_, err = UUIDv1Time("not-a-uuid")
+	assert.ErrorIs(t, err, ErrInvalidUUID)
+}
+
+func TestUUIDv7(t *testing.T) {
+	re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	before := time.Now().UnixMilli()
+	prev := ""
+	for i := 0; i < 10; i++ {
+		s := UUIDv7()
+		assert.Regexp(t, re, s)
+
+		u, err := ParseUUID(s)
+		assert.NoError(t, err)
+		var ms int64
+		for _, b := range u[:6] {
+			ms = ms<<8 | int64(b)
+		}
+		assert.True(t, ms >= before && ms <= time.Now().UnixMilli())
+
+		// the timestamp prefix never goes backwards
+		assert.True(t, prev[:min(len(prev), 13)] <= s[:13])
+		prev = s
+	}
+}
+
+func TestUUID(t *testing.T) {
+	for _, v := range []int{4, 7} {
+		s, err := UUID(v)
+		assert.NoError(t, err)
+		assert.Equal(t, byte('0'+v), s[14])
+		assert.Contains(t, "89ab", string(s[19]))
+	}
+
+	for _, v := range []int{0, 1, 3, 5, 6, 8} {
+		_, err := UUID(v)
+		assert.Error(t, err)
+	}
+}
+
+func TestUUIDVersion(t *testing.T) {
+	gens := map[int]func() string{
+		1: UUIDv1,
+		4: UUIDv4,
+		7: UUIDv7,
+	}
+	for want, gen := range gens {
+		for i := 0; i < 10; i++ {
+			v, err := UUIDVersion(gen())
+			assert.NoError(t, err)
+			assert.Equal(t, want, v)
+		}
+	}
+	for _, s := range []string{GUID(), UUIDv4Compact(), UUIDv4Upper(), TaggedUUID(3)} {
+		v, err := UUIDVersion(s)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, v)
+	}
+
+	// RFC 9562 test vectors for versions 3, 5, 6 and 8
+	vectors := map[string]int{
+		"5df41881-3aed-3515-88a7-2f4a814cf09e": 3,
+		"2ed6657d-e927-568b-95e1-2665a8aea6a2": 5,
+		"1ec9414c-232a-6b00-b3c8-9f6bdeced846": 6,
+		"2489e9ad-2ee2-8e00-8ec9-32d5f69181c0": 8,
+	}
+	for s, want := range vectors {
+		v, err := UUIDVersion(s)
+		assert.NoError(t, err)
+		assert.Equal(t, want, v)
+	}
+
+	invalid := []string{
+		"",
+		"not-a-uuid",
+		"00000000-0000-0000-0000-000000000000",
+		"9b2f7c1e-4d3a-4f6b-0e21-0c5d7a9b3e42",
+		"9b2f7c1e-4d3a-9f6b-8e21-0c5d7a9b3e42",
+	}
+	for _, s := range invalid {
+		_, err := UUIDVersion(s)
+		assert.ErrorIs(t, err, ErrInvalidUUID, s)
+	}
+}
+
+func TestParseUUID(t *testing.T) {
+	invalid := []string{
+		"",
+		"9b2f7c1e-4d3a-4f6b-8e21-0c5d7a9b3e4",
+		"9b2f7c1e4d3a-4f6b-8e21-0c5d7a9b3e42-",
+		"9b2f7c1e-4d3a-4f6b-8e21-0c5d7a9b3eXY",
+		"{9b2f7c1e-4d3a-4f6b-8e21-0c5d7a9b3e42",
+		"9b2f7c1e4d3a4f6b8e210c5d7a9b3eXY",
+	}
+	for _, s := range invalid {
+		_, err := ParseUUID(s)
+		assert.ErrorIs(t, err, ErrInvalidUUID, s)
+	}
+}
+
+func BenchmarkUUIDv4(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		UUIDv4()
+	}
+}
+
+func BenchmarkGUID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		GUID()
+	}
+}
+
+func BenchmarkUUIDv7(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		UUIDv7()
+	}
+}
+
+func BenchmarkTaggedUUID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		TaggedUUID(42)
+	}
+}
+
+func BenchmarkUUIDv1(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		UUIDv1()
+	}
+}
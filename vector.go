@@ -0,0 +1,35 @@
+package buuid
+
+import (
+	"errors"
+	"math"
+)
+
+// SparseVector generates a random sparse vector of dimension dim as a map from index to value.
+// Each index in [0, dim) is non-zero independently with probability density, so about density*dim
+// entries are present, and non-zero values are uniform in (0, maxVal).
+// dim must be non-negative, density in [0, 1] and maxVal positive.
+// example: SparseVector(10000, 0.01, 1)
+func SparseVector(dim int, density float64, maxVal float64) (map[int]float64, error) {
+	if dim < 0 {
+		return nil, errors.New("buuid: dim must be non-negative")
+	}
+	if !(density >= 0 && density <= 1) {
+		return nil, errors.New("buuid: density must be in [0, 1]")
+	}
+	if !(maxVal > 0) || math.IsInf(maxVal, 1) {
+		return nil, errors.New("buuid: maxVal must be positive")
+	}
+
+	indices := sortedDistinctInts(dim, binomial(dim, density))
+	vec := make(map[int]float64, len(indices))
+	for _, i := range indices {
+		v := randFloat() * maxVal
+		for v == 0 {
+			v = randFloat() * maxVal
+		}
+		vec[i] = v
+	}
+
+	return vec, nil
+}
@@ -0,0 +1,48 @@
+package buuid
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparseVector(t *testing.T) {
+	dim := 100000
+	for _, density := range []float64{0.001, 0.05, 0.5} {
+		vec, err := SparseVector(dim, density, 2.5)
+		assert.NoError(t, err)
+		// within 5 standard deviations of the binomial count
+		sd := math.Sqrt(float64(dim) * density * (1 - density))
+		assert.InDelta(t, density*float64(dim), len(vec), 5*sd)
+		for i, v := range vec {
+			assert.True(t, i >= 0 && i < dim)
+			assert.True(t, v > 0 && v < 2.5)
+		}
+	}
+
+	vec, err := SparseVector(50, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 50, len(vec))
+	vec, err = SparseVector(50, 0, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(vec))
+	vec, err = SparseVector(0, 0.5, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(vec))
+
+	_, err = SparseVector(-1, 0.5, 1)
+	assert.Error(t, err)
+	_, err = SparseVector(10, 1.5, 1)
+	assert.Error(t, err)
+	_, err = SparseVector(10, math.NaN(), 1)
+	assert.Error(t, err)
+	_, err = SparseVector(10, 0.5, 0)
+	assert.Error(t, err)
+}
+
+func BenchmarkSparseVector(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = SparseVector(10000, 0.01, 1)
+	}
+}
@@ -0,0 +1,36 @@
+package buuid
+
+import (
+	"strconv"
+)
+
+// SemVer generates a random semantic version MAJOR.MINOR.PATCH, each part in [0, 20].
+// example: 1.12.3
+func SemVer() string {
+	return strconv.Itoa(Int(0, 20)) + "." + strconv.Itoa(Int(0, 20)) + "." + strconv.Itoa(Int(0, 20))
+}
+
+// SemVerConstraint generates a random valid semver constraint, the supported forms are:
+//
+//	=1.2.3 >1.2.3 >=1.2.3 <1.2.3 <=1.2.3  comparison with a full version
+//	~1.2.3 ~1.4                          tilde, patch-level changes
+//	^1.2.3                               caret, compatible changes
+//	>=1.2.3 <2.0.0                       range of two comparisons with a lower bound below the upper bound
+func SemVerConstraint() string {
+	switch randIntn(5) {
+	case 0:
+		ops := []string{"=", ">", ">=", "<", "<="}
+		return ops[randIntn(len(ops))] + SemVer()
+	case 1:
+		return "~" + SemVer()
+	case 2:
+		return "~" + strconv.Itoa(Int(0, 20)) + "." + strconv.Itoa(Int(0, 20))
+	case 3:
+		return "^" + SemVer()
+	default:
+		major := Int(0, 19)
+		lower := strconv.Itoa(major) + "." + strconv.Itoa(Int(0, 20)) + "." + strconv.Itoa(Int(0, 20))
+		upper := strconv.Itoa(Int(major+1, 20)) + ".0.0"
+		return ">=" + lower + " <" + upper
+	}
+}
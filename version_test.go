@@ -0,0 +1,48 @@
+package buuid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSemVer(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		s := SemVer()
+		v, err := semver.StrictNewVersion(s)
+		assert.NoError(t, err, s)
+		if err == nil {
+			assert.Empty(t, v.Prerelease(), s)
+			assert.Empty(t, v.Metadata(), s)
+		}
+	}
+}
+
+func TestSemVerConstraint(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		s := SemVerConstraint()
+		_, err := semver.NewConstraint(s)
+		assert.NoError(t, err, s)
+
+		parts := strings.Split(s, " ")
+		assert.True(t, len(parts) == 1 || len(parts) == 2, s)
+		if len(parts) == 2 {
+			assert.True(t, strings.HasPrefix(parts[0], ">=") && strings.HasPrefix(parts[1], "<"), s)
+			lower, err := semver.StrictNewVersion(strings.TrimPrefix(parts[0], ">="))
+			assert.NoError(t, err, s)
+			upper, err := semver.StrictNewVersion(strings.TrimPrefix(parts[1], "<"))
+			assert.NoError(t, err, s)
+			if lower != nil && upper != nil {
+				assert.True(t, lower.LessThan(upper), s)
+			}
+		}
+	}
+}
+
+func BenchmarkSemVerConstraint(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		SemVerConstraint()
+	}
+}
@@ -0,0 +1,83 @@
+package buuid
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// maxQueryParams is the number of distinct 8 lowercase letter keys, 26^8,
+// a variable of type int64 as it does not fit in a 32-bit int.
+var maxQueryParams int64 = 208827064576
+
+// QueryParams generates n random query parameters with distinct keys,
+// keys are 8 lowercase letters and values are 12 alphanumeric characters, so no percent-encoding is needed.
+// An empty url.Values is returned if n <= 0, and n is capped at 26^8, the number of distinct keys.
+// example: QueryParams(3).Encode()
+func QueryParams(n int) url.Values {
+	if n <= 0 {
+		return url.Values{}
+	}
+	if int64(n) > maxQueryParams {
+		n = int(maxQueryParams)
+	}
+
+	values := make(url.Values, n)
+	for len(values) < n {
+		key := String(R_LOWER, 8)
+		if _, ok := values[key]; ok {
+			continue
+		}
+		values.Set(key, String(R_All, 12))
+	}
+	return values
+}
+
+// DataURI generates a data URI of byteLen random bytes, data:<mime>;base64,<payload>.
+// example: DataURI("image/png", 64)
+func DataURI(mime string, byteLen int) (string, error) {
+	if mime == "" {
+		return "", errors.New("buuid: mime must not be empty")
+	}
+	if byteLen < 0 {
+		return "", errors.New("buuid: byteLen must be non-negative")
+	}
+
+	b := make([]byte, byteLen)
+	readRandom(b)
+
+	return "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(b), nil
+}
+
+// userAgentPlatforms holds the desktop platform tokens UserAgent combines with Chrome, Edge and Firefox.
+var userAgentPlatforms = []string{
+	"Windows NT 10.0; Win64; x64",
+	"Macintosh; Intel Mac OS X 10_15_7",
+	"X11; Linux x86_64",
+	"X11; Ubuntu; Linux x86_64",
+}
+
+// UserAgent generates a plausible but synthetic browser User-Agent string for Chrome, Edge, Firefox or Safari
+// on a random desktop platform, with a recent random major version. It is meant for HTTP and analytics
+// fixtures, not for impersonating a real browser.
+// example: Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.6367.91 Safari/537.36
+func UserAgent() string {
+	platform := userAgentPlatforms[randIntn(len(userAgentPlatforms))]
+
+	switch randIntn(4) {
+	case 0:
+		return fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%d.0.%d.%d Safari/537.36",
+			platform, Int(110, 130), Int(5000, 6999), Int(0, 200))
+	case 1:
+		major := Int(110, 130)
+		return fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%d.0.0.0 Safari/537.36 Edg/%d.0.%d.%d",
+			platform, major, major, Int(1500, 2999), Int(0, 100))
+	case 2:
+		major := Int(110, 130)
+		return fmt.Sprintf("Mozilla/5.0 (%s; rv:%d.0) Gecko/20100101 Firefox/%d.0", platform, major, major)
+	default:
+		return fmt.Sprintf("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/%d.%d Safari/605.1.15",
+			Int(15, 17), Int(0, 6))
+	}
+}
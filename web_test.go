@@ -0,0 +1,91 @@
+package buuid
+
+import (
+	"encoding/base64"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryParams(t *testing.T) {
+	assert.Equal(t, 0, len(QueryParams(0)))
+	assert.Equal(t, 0, len(QueryParams(-1)))
+	assert.NotNil(t, QueryParams(-1))
+	assert.Equal(t, maxQueryParams, int64(math.Pow(26, 8)))
+
+	for i := 0; i < 10; i++ {
+		values := QueryParams(20)
+		assert.Equal(t, 20, len(values))
+
+		parsed, err := url.ParseQuery(values.Encode())
+		assert.NoError(t, err)
+		assert.Equal(t, values, parsed)
+		for _, v := range parsed {
+			assert.Equal(t, 1, len(v))
+		}
+	}
+}
+
+func TestDataURI(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 64, 100} {
+		s, err := DataURI("image/png", n)
+		assert.NoError(t, err)
+
+		mime, payload, ok := strings.Cut(strings.TrimPrefix(s, "data:"), ";base64,")
+		assert.True(t, ok)
+		assert.True(t, strings.HasPrefix(s, "data:"))
+		assert.Equal(t, "image/png", mime)
+
+		b, err := base64.StdEncoding.DecodeString(payload)
+		assert.NoError(t, err)
+		assert.Equal(t, n, len(b))
+	}
+
+	_, err := DataURI("", 10)
+	assert.Error(t, err)
+	_, err = DataURI("text/plain", -1)
+	assert.Error(t, err)
+}
+
+func TestUserAgent(t *testing.T) {
+	browser := regexp.MustCompile(`(Chrome|Firefox|Version)/\d+\.\d+`)
+	seen := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		ua := UserAgent()
+		assert.True(t, strings.HasPrefix(ua, "Mozilla/5.0 ("), ua)
+		assert.Regexp(t, browser, ua)
+
+		switch {
+		case strings.Contains(ua, "Edg/"):
+			seen["edge"] = true
+			assert.Contains(t, ua, "Chrome/")
+		case strings.Contains(ua, "Chrome/"):
+			seen["chrome"] = true
+			assert.True(t, strings.HasSuffix(ua, "Safari/537.36"), ua)
+		case strings.Contains(ua, "Firefox/"):
+			seen["firefox"] = true
+			assert.Contains(t, ua, "Gecko/20100101")
+		default:
+			seen["safari"] = true
+			assert.Contains(t, ua, "Macintosh")
+			assert.True(t, strings.HasSuffix(ua, "Safari/605.1.15"), ua)
+		}
+	}
+	assert.Equal(t, 4, len(seen))
+}
+
+func BenchmarkQueryParams_10(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		QueryParams(10)
+	}
+}
+
+func BenchmarkUserAgent(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		UserAgent()
+	}
+}
@@ -0,0 +1,124 @@
+package buuid
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	slugAdjectives = []string{
+		"agile", "amber", "ancient", "bold", "brave", "bright", "calm", "clever", "cosmic", "crimson",
+		"curious", "daring", "eager", "fancy", "fierce", "gentle", "golden", "happy", "hidden", "humble",
+		"jolly", "keen", "lively", "lucky", "mellow", "misty", "noble", "orange", "polite", "proud",
+		"quiet", "rapid", "royal", "rustic", "shiny", "silent", "silver", "smooth", "snowy", "sunny",
+		"swift", "tidy", "vivid", "wild", "wise", "witty", "young", "zesty",
+	}
+	slugNouns = []string{
+		"badger", "bear", "beacon", "breeze", "canyon", "cedar", "comet", "coral", "crane", "delta",
+		"eagle", "falcon", "fern", "forest", "fox", "glacier", "harbor", "hawk", "heron", "island",
+		"lake", "lion", "lotus", "maple", "meadow", "meteor", "moon", "otter", "owl", "panda",
+		"pebble", "pine", "planet", "raven", "river", "rocket", "sparrow", "star", "stone", "storm",
+		"summit", "tiger", "valley", "wave", "willow", "wolf", "zebra",
+	}
+)
+
+// Slug generates a random lowercase hyphenated slug of words words, adjectives followed by a noun,
+// default is 3 words if words <= 0. If withNumber is true a number in [0, 99] is appended.
+// example: Slug(3) returns "brave-orange-falcon", Slug(3, true) returns "brave-orange-falcon-42"
+func Slug(words int, withNumber ...bool) string {
+	if words <= 0 {
+		words = 3
+	}
+
+	parts := make([]string, 0, words+1)
+	for i := 0; i < words-1; i++ {
+		parts = append(parts, slugAdjectives[randIntn(len(slugAdjectives))])
+	}
+	parts = append(parts, slugNouns[randIntn(len(slugNouns))])
+	if len(withNumber) > 0 && withNumber[0] {
+		parts = append(parts, strconv.Itoa(Int(0, 99)))
+	}
+
+	return strings.Join(parts, "-")
+}
+
+// Consonants and vowels alternated by Pronounceable, letters that are easily misheard
+// or awkward to say (c, q, w, x, y) are left out.
+var (
+	PronounceableConsonants = "bdfghjklmnprstvz"
+	PronounceableVowels     = "aeiou"
+)
+
+// Pronounceable generates a random easy-to-say code of syllables consonant+vowel pairs,
+// the length is syllables*2, default is 3 syllables if syllables <= 0.
+// example: Pronounceable(3) returns something like "bolaku"
+func Pronounceable(syllables int) string {
+	if syllables <= 0 {
+		syllables = 3
+	}
+
+	result := make([]byte, 0, syllables*2)
+	for i := 0; i < syllables; i++ {
+		result = append(result,
+			PronounceableConsonants[randIntn(len(PronounceableConsonants))],
+			PronounceableVowels[randIntn(len(PronounceableVowels))],
+		)
+	}
+
+	return string(result)
+}
+
+// LoremWords holds the lowercase lorem ipsum words Paragraphs draws from.
+var LoremWords = []string{
+	"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do",
+	"eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua", "enim",
+	"ad", "minim", "veniam", "quis", "nostrud", "exercitation", "ullamco", "laboris", "nisi", "aliquip",
+	"ex", "ea", "commodo", "consequat", "duis", "aute", "irure", "in", "reprehenderit", "voluptate",
+	"velit", "esse", "cillum", "fugiat", "nulla", "pariatur", "excepteur", "sint", "occaecat", "cupidatat",
+	"non", "proident", "sunt", "culpa", "qui", "officia", "deserunt", "mollit", "anim", "id", "est", "laborum",
+}
+
+// Paragraphs generates count paragraphs of lorem ipsum filler text separated by a blank line ("\n\n").
+// A paragraph is a number of sentences in the inclusive range sentencesPerPara joined by single spaces,
+// a sentence is a number of words from LoremWords in the inclusive range wordsPerSentence, capitalized
+// and ending with a period. count must be positive and each range [min, max] must have 1 <= min <= max.
+// example: Paragraphs(2, [2]int{3, 5}, [2]int{6, 12})
+func Paragraphs(count int, sentencesPerPara [2]int, wordsPerSentence [2]int) (string, error) {
+	if count < 1 {
+		return "", errors.New("buuid: count must be positive")
+	}
+	if sentencesPerPara[0] < 1 || sentencesPerPara[0] > sentencesPerPara[1] {
+		return "", errors.New("buuid: sentencesPerPara must satisfy 1 <= min <= max")
+	}
+	if wordsPerSentence[0] < 1 || wordsPerSentence[0] > wordsPerSentence[1] {
+		return "", errors.New("buuid: wordsPerSentence must satisfy 1 <= min <= max")
+	}
+
+	var b strings.Builder
+	for p := 0; p < count; p++ {
+		if p > 0 {
+			b.WriteString("\n\n")
+		}
+		sentences := Int(sentencesPerPara[0], sentencesPerPara[1])
+		for i := 0; i < sentences; i++ {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			words := Int(wordsPerSentence[0], wordsPerSentence[1])
+			for j := 0; j < words; j++ {
+				w := LoremWords[randIntn(len(LoremWords))]
+				if j == 0 {
+					b.WriteString(strings.ToUpper(w[:1]))
+					w = w[1:]
+				} else {
+					b.WriteByte(' ')
+				}
+				b.WriteString(w)
+			}
+			b.WriteByte('.')
+		}
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,107 @@
+package buuid
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlug(t *testing.T) {
+	re := regexp.MustCompile(`^[a-z]+(-[a-z]+)*$`)
+	assert.Equal(t, 3, len(strings.Split(Slug(0), "-")))
+
+	for _, words := range []int{1, 2, 3, 5} {
+		for i := 0; i < 100; i++ {
+			s := Slug(words)
+			assert.Regexp(t, re, s)
+			assert.Equal(t, words, len(strings.Split(s, "-")))
+			assert.Equal(t, s, url.PathEscape(s))
+
+			parts := strings.Split(s, "-")
+			assert.Contains(t, slugNouns, parts[len(parts)-1])
+			for _, p := range parts[:len(parts)-1] {
+				assert.Contains(t, slugAdjectives, p)
+			}
+		}
+	}
+
+	numbered := regexp.MustCompile(`^[a-z]+(-[a-z]+){2}-[0-9]{1,2}$`)
+	for i := 0; i < 100; i++ {
+		s := Slug(3, true)
+		assert.Regexp(t, numbered, s)
+		assert.Equal(t, s, url.PathEscape(s))
+	}
+}
+
+func TestPronounceable(t *testing.T) {
+	assert.Equal(t, 6, len(Pronounceable(0)))
+
+	for _, n := range []int{1, 3, 8} {
+		for i := 0; i < 100; i++ {
+			s := Pronounceable(n)
+			assert.Equal(t, n*2, len(s))
+			for j := 0; j < len(s); j++ {
+				if j%2 == 0 {
+					assert.True(t, strings.IndexByte(PronounceableConsonants, s[j]) >= 0, s)
+				} else {
+					assert.True(t, strings.IndexByte(PronounceableVowels, s[j]) >= 0, s)
+				}
+			}
+		}
+	}
+}
+
+func TestParagraphs(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		s, err := Paragraphs(3, [2]int{2, 4}, [2]int{5, 9})
+		assert.NoError(t, err)
+
+		paras := strings.Split(s, "\n\n")
+		assert.Equal(t, 3, len(paras))
+		for _, p := range paras {
+			assert.True(t, strings.HasSuffix(p, "."))
+			sentences := strings.Split(strings.TrimSuffix(p, "."), ". ")
+			assert.True(t, len(sentences) >= 2 && len(sentences) <= 4, p)
+			for _, sentence := range sentences {
+				assert.True(t, sentence[0] >= 'A' && sentence[0] <= 'Z')
+				words := strings.Split(strings.ToLower(sentence), " ")
+				assert.True(t, len(words) >= 5 && len(words) <= 9, sentence)
+				for _, w := range words {
+					assert.Contains(t, LoremWords, w)
+				}
+			}
+		}
+	}
+
+	s, err := Paragraphs(1, [2]int{1, 1}, [2]int{1, 1})
+	assert.NoError(t, err)
+	assert.Regexp(t, `^[A-Z][a-z]*\.$`, s)
+
+	_, err = Paragraphs(0, [2]int{1, 2}, [2]int{1, 2})
+	assert.Error(t, err)
+	_, err = Paragraphs(1, [2]int{0, 2}, [2]int{1, 2})
+	assert.Error(t, err)
+	_, err = Paragraphs(1, [2]int{1, 2}, [2]int{3, 2})
+	assert.Error(t, err)
+}
+
+func BenchmarkSlug(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Slug(3, true)
+	}
+}
+
+func BenchmarkPronounceable(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Pronounceable(3)
+	}
+}
+
+func BenchmarkParagraphs(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = Paragraphs(3, [2]int{3, 5}, [2]int{6, 12})
+	}
+}